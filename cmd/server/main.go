@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
@@ -14,7 +15,9 @@ import (
 	"nats-graphql/graph"
 	"nats-graphql/middleware"
 	natsclient "nats-graphql/nats"
+	"nats-graphql/objects"
 	"nats-graphql/playground"
+	"nats-graphql/scheduler"
 )
 
 func main() {
@@ -35,6 +38,19 @@ func main() {
 
 	log.Printf("Connected to NATS at %s", nc.ConnectedUrl())
 
+	// Durable scheduler for publishScheduled, re-arms pending messages on startup.
+	// Optional: without it the rest of the API keeps working.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sched, err := scheduler.New(ctx, js)
+	if err == nil {
+		err = sched.Start(ctx)
+	}
+	if err != nil {
+		log.Printf("Warning: scheduler disabled: %v", err)
+		sched = nil
+	}
+
 	// Log configuration
 	authMode := "disabled"
 	if os.Getenv("AUTH_TOKEN") != "" {
@@ -45,7 +61,7 @@ func main() {
 
 	// GraphQL server with WebSocket support for subscriptions
 	srv := handler.New(graph.NewExecutableSchema(graph.Config{
-		Resolvers: &graph.Resolver{NC: nc, JS: js},
+		Resolvers: &graph.Resolver{NC: nc, JS: js, Scheduler: sched},
 	}))
 	srv.AddTransport(transport.Options{})
 	srv.AddTransport(transport.GET{})
@@ -58,6 +74,7 @@ func main() {
 	mux := http.NewServeMux()
 	mux.Handle("/", playground.Handler("NATS GraphQL", "/query"))
 	mux.Handle("/query", middleware.Auth(srv))
+	mux.Handle("/objects/", middleware.Auth(objects.Handler(js)))
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("ok"))
@@ -14,6 +14,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
+	"github.com/nats-io/nats.go/micro"
 )
 
 // GraphQL request/response types
@@ -25,7 +26,8 @@ type gqlRequest struct {
 type gqlResponse struct {
 	Data   json.RawMessage `json:"data"`
 	Errors []struct {
-		Message string `json:"message"`
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
 	} `json:"errors"`
 }
 
@@ -82,6 +84,28 @@ func queryExpectError(q string) string {
 	return ""
 }
 
+// queryExpectErrorCode runs a query that should fail and returns the
+// "extensions.code" of the first error (empty if none).
+func queryExpectErrorCode(q string) string {
+	body, _ := json.Marshal(gqlRequest{Query: q})
+	resp, err := http.Post(baseURL+"/query", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+
+	raw, _ := io.ReadAll(resp.Body)
+	var gql gqlResponse
+	if err := json.Unmarshal(raw, &gql); err != nil {
+		return ""
+	}
+	if len(gql.Errors) == 0 {
+		return ""
+	}
+	code, _ := gql.Errors[0].Extensions["code"].(string)
+	return code
+}
+
 func httpGet(path string) (*http.Response, string) {
 	resp, err := http.Get(baseURL + path)
 	if err != nil {
@@ -108,6 +132,68 @@ func unmarshal[T any](data json.RawMessage, field string) T {
 	return wrapper[field]
 }
 
+// connectWS opens a WebSocket to /query using the graphql-transport-ws
+// subprotocol and completes the connection_init handshake.
+func connectWS() (*websocket.Conn, error) {
+	wsURL := strings.Replace(baseURL, "http://", "ws://", 1)
+	wsURL = strings.Replace(wsURL, "https://", "wss://", 1)
+	wsURL += "/query"
+
+	dialer := websocket.Dialer{}
+	header := http.Header{}
+	header.Set("Sec-WebSocket-Protocol", "graphql-transport-ws")
+	conn, _, err := dialer.Dial(wsURL, header)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send connection_init
+	init := map[string]any{"type": "connection_init"}
+	conn.WriteJSON(init)
+
+	// Wait for connection_ack
+	var ack map[string]any
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	conn.ReadJSON(&ack)
+	if ack["type"] != "connection_ack" {
+		conn.Close()
+		return nil, fmt.Errorf("expected connection_ack, got: %v", ack["type"])
+	}
+
+	return conn, nil
+}
+
+// subscribeWS sends a subscribe message with the given id and query.
+func subscribeWS(conn *websocket.Conn, id, q string) {
+	conn.WriteJSON(map[string]any{
+		"id":   id,
+		"type": "subscribe",
+		"payload": map[string]any{
+			"query": q,
+		},
+	})
+}
+
+// readWSNext reads the next subscription message and returns the payload
+// for the given field. Returns an error on timeout or unexpected message.
+func readWSNext(conn *websocket.Conn, field string) (map[string]any, error) {
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var msg map[string]any
+	if err := conn.ReadJSON(&msg); err != nil {
+		return nil, err
+	}
+	if msg["type"] != "next" {
+		return nil, fmt.Errorf("expected 'next', got: %v (%v)", msg["type"], msg["payload"])
+	}
+	payload, _ := msg["payload"].(map[string]any)
+	data, _ := payload["data"].(map[string]any)
+	result, ok := data[field].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("missing %s in payload: %v", field, payload)
+	}
+	return result, nil
+}
+
 // ══════════════════════════════════════════════════════════════════
 // HEALTHZ TESTS
 // ══════════════════════════════════════════════════════════════════
@@ -336,6 +422,132 @@ func testStreamsWithMessages() {
 	assert("test stream found", false, "not in list")
 }
 
+func testStreamByName() {
+	fmt.Println("\n── stream(name) ──")
+
+	data, err := query(fmt.Sprintf(`{
+		stream(name: "%s") {
+			name messages description discard duplicates allowRollup denyDelete denyPurge
+			firstSeq lastSeq firstTs lastTs numDeleted numSubjects
+		}
+	}`, testStream))
+	assert("query executes", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+
+	type streamInfo struct {
+		Name        string  `json:"name"`
+		Messages    int     `json:"messages"`
+		Description *string `json:"description"`
+		Discard     string  `json:"discard"`
+		Duplicates  int     `json:"duplicates"`
+		DenyDelete  bool    `json:"denyDelete"`
+		FirstSeq    int     `json:"firstSeq"`
+		LastSeq     int     `json:"lastSeq"`
+		FirstTs     *string `json:"firstTs"`
+		LastTs      *string `json:"lastTs"`
+		NumSubjects int     `json:"numSubjects"`
+	}
+	si := unmarshal[streamInfo](data, "stream")
+	assert("name matches", si.Name == testStream, "got: "+si.Name)
+	assert("discard is DiscardOld", si.Discard == "DiscardOld", "got: "+si.Discard)
+	assert("duplicates window is set", si.Duplicates > 0, fmt.Sprintf("got: %d", si.Duplicates))
+	assert("description is null", si.Description == nil, "expected null")
+	assert("denyDelete is false", !si.DenyDelete, "expected false")
+	assert("lastSeq >= firstSeq", si.LastSeq >= si.FirstSeq, fmt.Sprintf("first: %d, last: %d", si.FirstSeq, si.LastSeq))
+	if si.Messages > 0 {
+		assert("firstTs is set", si.FirstTs != nil, "expected timestamp")
+		assert("numSubjects > 0", si.NumSubjects > 0, fmt.Sprintf("got: %d", si.NumSubjects))
+	}
+	assert("lastTs is set", si.LastTs != nil, "expected timestamp")
+
+	data, err = query(`{ stream(name: "__no_such_stream__") { name } }`)
+	assert("missing stream query executes", err == nil, fmt.Sprint(err))
+	if err == nil {
+		assert("missing stream returns null", string(data) == `{"stream":null}`, "got: "+string(data))
+	}
+}
+
+func testStreamSubjectCounts() {
+	fmt.Println("\n── stream.subjectCounts ──")
+
+	for _, subj := range []string{"counts.a", "counts.a", "counts.b"} {
+		_, err := js.Publish(context.Background(), testStream+"."+subj, []byte("x"))
+		if err != nil {
+			assert("publish message", false, fmt.Sprint(err))
+			return
+		}
+	}
+
+	type subjectCount struct {
+		Subject  string `json:"subject"`
+		Messages int    `json:"messages"`
+	}
+	type streamInfo struct {
+		SubjectCounts []subjectCount `json:"subjectCounts"`
+	}
+
+	data, err := query(fmt.Sprintf(`{ stream(name: "%s") { subjectCounts(filter: "%s.counts.*") { subject messages } } }`, testStream, testStream))
+	assert("query executes", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+
+	counts := unmarshal[streamInfo](data, "stream").SubjectCounts
+	assert("2 subjects match filter", len(counts) == 2, fmt.Sprintf("got: %d", len(counts)))
+	if len(counts) == 2 {
+		assert("sorted by subject", counts[0].Subject == testStream+".counts.a", "got: "+counts[0].Subject)
+		assert("counts.a has 2 messages", counts[0].Messages == 2, fmt.Sprintf("got: %d", counts[0].Messages))
+		assert("counts.b has 1 message", counts[1].Messages == 1, fmt.Sprintf("got: %d", counts[1].Messages))
+	}
+}
+
+func testAccountInfo() {
+	fmt.Println("\n── accountInfo ──")
+
+	type accountLimits struct {
+		MaxMemory  int `json:"maxMemory"`
+		MaxStorage int `json:"maxStorage"`
+		MaxStreams int `json:"maxStreams"`
+	}
+	type accountInfo struct {
+		Storage   int           `json:"storage"`
+		Memory    int           `json:"memory"`
+		Streams   int           `json:"streams"`
+		Consumers int           `json:"consumers"`
+		Limits    accountLimits `json:"limits"`
+		API       struct {
+			Total  int `json:"total"`
+			Errors int `json:"errors"`
+		} `json:"api"`
+		Tiers []struct {
+			Name string `json:"name"`
+		} `json:"tiers"`
+	}
+
+	data, err := query(`{ accountInfo { storage memory streams consumers limits { maxMemory maxStorage maxStreams } api { total errors } tiers { name } } }`)
+	assert("query executes", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+
+	info := unmarshal[accountInfo](data, "accountInfo")
+	assert("at least one stream", info.Streams >= 1, fmt.Sprintf("got: %d", info.Streams))
+	assert("storage is used", info.Storage > 0, fmt.Sprintf("got: %d", info.Storage))
+	assert("api calls counted", info.API.Total > 0, fmt.Sprintf("got: %d", info.API.Total))
+	assert("tiers is a list", info.Tiers != nil, "expected non-null tiers")
+
+	// Every stream, including KV and Object Store buckets, counts towards the account
+	streams, err := query(`{ streams { name } }`)
+	if err == nil {
+		list := unmarshal[[]struct {
+			Name string `json:"name"`
+		}](streams, "streams")
+		assert("stream count matches streams query", info.Streams == len(list), fmt.Sprintf("account: %d, streams: %d", info.Streams, len(list)))
+	}
+}
+
 // ══════════════════════════════════════════════════════════════════
 // KV PUT TESTS
 // ══════════════════════════════════════════════════════════════════
@@ -525,6 +737,132 @@ func testKvDelete() {
 	}
 }
 
+// ══════════════════════════════════════════════════════════════════
+// KV OPTIMISTIC CONCURRENCY TESTS
+// ══════════════════════════════════════════════════════════════════
+
+func testKvCreateKeyAndUpdateKey() {
+	fmt.Println("\n── kvCreateKey / kvUpdateKey ──")
+
+	type entry struct {
+		Key      string `json:"key"`
+		Value    string `json:"value"`
+		Revision int    `json:"revision"`
+	}
+
+	q := fmt.Sprintf(`mutation { kvCreateKey(bucket: "%s", key: "cas", value: "v1") { key value revision } }`, testBucket)
+	data, err := query(q)
+	assert("create new key", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+	e := unmarshal[entry](data, "kvCreateKey")
+	assert("created value matches", e.Value == "v1", "got: "+e.Value)
+	rev := e.Revision
+
+	code := queryExpectErrorCode(q)
+	assert("create existing key fails with KEY_EXISTS", code == "KEY_EXISTS", "got: "+code)
+
+	q = fmt.Sprintf(`mutation { kvUpdateKey(bucket: "%s", key: "cas", value: "v2", revision: %d) { value revision } }`, testBucket, rev)
+	data, err = query(q)
+	assert("update with current revision", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+	e = unmarshal[entry](data, "kvUpdateKey")
+	assert("updated value matches", e.Value == "v2", "got: "+e.Value)
+	assert("revision incremented", e.Revision > rev, fmt.Sprintf("got: %d, prev: %d", e.Revision, rev))
+
+	// Same (now stale) revision must be rejected
+	code = queryExpectErrorCode(q)
+	assert("update with stale revision fails with REVISION_MISMATCH", code == "REVISION_MISMATCH", "got: "+code)
+}
+
+func testKvHistory() {
+	fmt.Println("\n── kvHistory ──")
+
+	for _, v := range []string{"h1", "h2", "h3"} {
+		query(fmt.Sprintf(`mutation { kvPut(bucket: "%s", key: "hist", value: "%s") { revision } }`, testBucket, v))
+	}
+	query(fmt.Sprintf(`mutation { kvDelete(bucket: "%s", key: "hist") }`, testBucket))
+
+	type entry struct {
+		Value     string `json:"value"`
+		Revision  int    `json:"revision"`
+		Operation string `json:"operation"`
+	}
+
+	data, err := query(fmt.Sprintf(`{ kvHistory(bucket: "%s", key: "hist") { value revision operation } }`, testBucket))
+	assert("query executes", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+
+	// Test bucket keeps only 1 revision by default — the delete marker
+	entries := unmarshal[[]entry](data, "kvHistory")
+	assert("returns at least one revision", len(entries) >= 1, fmt.Sprintf("got: %d", len(entries)))
+	if len(entries) > 0 {
+		last := entries[len(entries)-1]
+		assert("latest revision is DEL marker", last.Operation == "DEL", "got: "+last.Operation)
+	}
+
+	data, err = query(fmt.Sprintf(`{ kvHistory(bucket: "%s", key: "never-existed") { value } }`, testBucket))
+	assert("missing key query executes", err == nil, fmt.Sprint(err))
+	if err == nil {
+		entries = unmarshal[[]entry](data, "kvHistory")
+		assert("missing key returns empty list", len(entries) == 0, fmt.Sprintf("got: %d", len(entries)))
+	}
+
+	errMsg := queryExpectError(fmt.Sprintf(`{ kvHistory(bucket: "%s", key: "hist", limit: 0) { value } }`, testBucket))
+	assert("limit 0 returns error", errMsg != "", "expected error")
+}
+
+func testKvRevisionAndRevert() {
+	fmt.Println("\n── kvGet(revision) / kvRevert ──")
+
+	// Default test bucket keeps a single revision, use a dedicated one with history
+	bucket := testBucket + "_hist"
+	_, err := query(fmt.Sprintf(`mutation { kvCreate(bucket: "%s", history: 5) { bucket } }`, bucket))
+	assert("create bucket with history", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+	defer query(fmt.Sprintf(`mutation { kvDeleteBucket(bucket: "%s") }`, bucket))
+
+	type entry struct {
+		Value    string `json:"value"`
+		Revision int    `json:"revision"`
+	}
+
+	data, _ := query(fmt.Sprintf(`mutation { kvPut(bucket: "%s", key: "cfg", value: "good") { revision } }`, bucket))
+	rev1 := unmarshal[entry](data, "kvPut").Revision
+	query(fmt.Sprintf(`mutation { kvPut(bucket: "%s", key: "cfg", value: "bad") { revision } }`, bucket))
+
+	data, err = query(fmt.Sprintf(`{ kvGet(bucket: "%s", key: "cfg", revision: %d) { value revision } }`, bucket, rev1))
+	assert("get old revision", err == nil, fmt.Sprint(err))
+	if err == nil {
+		e := unmarshal[entry](data, "kvGet")
+		assert("old revision value", e.Value == "good", "got: "+e.Value)
+		assert("old revision number", e.Revision == rev1, fmt.Sprintf("got: %d", e.Revision))
+	}
+
+	data, err = query(fmt.Sprintf(`mutation { kvRevert(bucket: "%s", key: "cfg", revision: %d) { value revision } }`, bucket, rev1))
+	assert("revert to old revision", err == nil, fmt.Sprint(err))
+	if err == nil {
+		e := unmarshal[entry](data, "kvRevert")
+		assert("reverted value", e.Value == "good", "got: "+e.Value)
+		assert("revert creates new revision", e.Revision > rev1+1, fmt.Sprintf("got: %d", e.Revision))
+	}
+
+	data, err = query(fmt.Sprintf(`{ kvGet(bucket: "%s", key: "cfg") { value } }`, bucket))
+	if err == nil {
+		assert("latest value is reverted", unmarshal[entry](data, "kvGet").Value == "good", "")
+	}
+
+	errMsg := queryExpectError(fmt.Sprintf(`mutation { kvRevert(bucket: "%s", key: "cfg", revision: 9999) { value } }`, bucket))
+	assert("revert to missing revision returns error", errMsg != "", "expected error")
+}
+
 // ══════════════════════════════════════════════════════════════════
 // ERROR HANDLING TESTS
 // ══════════════════════════════════════════════════════════════════
@@ -804,129 +1142,495 @@ func testPublishErrors() {
 	assert("payload limit documented", true, "1MB limit enforced in resolver")
 }
 
-// ══════════════════════════════════════════════════════════════════
-// STREAM PURGE TESTS
-// ══════════════════════════════════════════════════════════════════
+func testPublishScheduled() {
+	fmt.Println("\n── publishScheduled ──")
 
-func testStreamPurge() {
-	fmt.Println("\n── streamPurge ──")
+	subject := testStream + ".scheduled"
+	data, err := query(fmt.Sprintf(`mutation { publishScheduled(subject: "%s", data: "later", delay: 2) }`, subject))
+	assert("schedule message", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+	assert("schedule ID returned", unmarshal[string](data, "publishScheduled") != "", "empty ID")
 
-	// Publish some messages first
-	for i := 1; i <= 3; i++ {
-		q := fmt.Sprintf(`mutation { publish(subject: "%s.purge.%d", data: "purge-msg-%d") { sequence } }`, testStream, i, i)
-		_, err := query(q)
-		if err != nil {
-			assert(fmt.Sprintf("publish msg %d for purge test", i), false, fmt.Sprint(err))
-			return
+	// Pending messages are persisted in the schedule bucket
+	keys, err := js.KeyValue(context.Background(), "nats_graphql_schedules")
+	assert("schedule bucket exists", err == nil, fmt.Sprint(err))
+	if err == nil {
+		lister, err := keys.ListKeys(context.Background())
+		n := 0
+		if err == nil {
+			for range lister.Keys() {
+				n++
+			}
 		}
+		assert("pending message stored", n > 0, fmt.Sprintf("got %d keys", n))
 	}
-	assert("published 3 messages for purge test", true, "")
 
-	// Verify messages exist
-	q := `{ streams { name messages } }`
-	data, err := query(q)
-	assert("query streams before purge", err == nil, fmt.Sprint(err))
-	if err != nil {
-		return
-	}
+	data, err = query(fmt.Sprintf(`{ streamLastMessage(stream: "%s", subject: "%s") { sequence } }`, testStream, subject))
+	assert("not published before delay", err == nil && string(data) == `{"streamLastMessage":null}`, fmt.Sprintf("got: %s %v", data, err))
 
-	type streamInfo struct {
-		Name     string `json:"name"`
-		Messages int    `json:"messages"`
+	time.Sleep(4 * time.Second)
+
+	type lastMsg struct {
+		Data string `json:"data"`
 	}
-	var result struct {
-		Streams []streamInfo `json:"streams"`
+	data, err = query(fmt.Sprintf(`{ streamLastMessage(stream: "%s", subject: "%s") { data } }`, testStream, subject))
+	assert("published after delay", err == nil, fmt.Sprint(err))
+	if err == nil {
+		m := unmarshal[*lastMsg](data, "streamLastMessage")
+		assert("scheduled payload delivered", m != nil && m.Data == "later", fmt.Sprintf("got: %s", data))
 	}
-	json.Unmarshal(data, &result)
 
-	var msgsBefore int
-	for _, s := range result.Streams {
-		if s.Name == testStream {
-			msgsBefore = s.Messages
+	errMsg := queryExpectError(`mutation { publishScheduled(subject: "__no_stream_matches_this__.test", data: "x", delay: 5) }`)
+	assert("no matching stream returns error", errMsg != "", "expected error")
+	errMsg = queryExpectError(fmt.Sprintf(`mutation { publishScheduled(subject: "%s", data: "x", delay: 0) }`, subject))
+	assert("delay=0 returns error", errMsg != "", "expected error")
+}
+
+func testScheduledManagement() {
+	fmt.Println("\n── scheduledMessages / scheduledCancel / scheduledReschedule ──")
+
+	type scheduled struct {
+		ID      string `json:"id"`
+		Subject string `json:"subject"`
+		Data    string `json:"data"`
+		FireAt  string `json:"fireAt"`
+	}
+	find := func(id string) *scheduled {
+		data, err := query(`{ scheduledMessages { id subject data fireAt } }`)
+		if err != nil {
+			return nil
+		}
+		for _, m := range unmarshal[[]scheduled](data, "scheduledMessages") {
+			if m.ID == id {
+				return &m
+			}
 		}
+		return nil
 	}
-	assert("messages > 0 before purge", msgsBefore > 0, fmt.Sprintf("got: %d", msgsBefore))
 
-	// Purge the stream
-	q = fmt.Sprintf(`mutation { streamPurge(name: "%s") }`, testStream)
-	data, err = query(q)
-	assert("purge stream", err == nil, fmt.Sprint(err))
+	subject := testStream + ".retract"
+	data, err := query(fmt.Sprintf(`mutation { publishScheduled(subject: "%s", data: "oops", delay: 3600) }`, subject))
+	assert("schedule message", err == nil, fmt.Sprint(err))
 	if err != nil {
 		return
 	}
-	val := unmarshal[bool](data, "streamPurge")
-	assert("returns true", val, "got false")
+	id := unmarshal[string](data, "publishScheduled")
 
-	// Verify messages are gone
-	q = `{ streams { name messages } }`
-	data, err = query(q)
-	assert("query streams after purge", err == nil, fmt.Sprint(err))
-	if err != nil {
-		return
+	m := find(id)
+	assert("listed in scheduledMessages", m != nil, "not found: "+id)
+	if m != nil {
+		assert("subject listed", m.Subject == subject, "got: "+m.Subject)
+		assert("data listed", m.Data == "oops", "got: "+m.Data)
 	}
-	json.Unmarshal(data, &result)
 
-	for _, s := range result.Streams {
-		if s.Name == testStream {
-			assert("messages == 0 after purge", s.Messages == 0, fmt.Sprintf("got: %d", s.Messages))
-			return
-		}
+	// Push it back by another hour
+	data, err = query(fmt.Sprintf(`mutation { scheduledReschedule(id: "%s", delay: 7200) { id fireAt } }`, id))
+	assert("reschedule message", err == nil, fmt.Sprint(err))
+	if err == nil && m != nil {
+		r := unmarshal[scheduled](data, "scheduledReschedule")
+		before, _ := time.Parse(time.RFC3339, m.FireAt)
+		after, _ := time.Parse(time.RFC3339, r.FireAt)
+		assert("fireAt moved later", after.Sub(before) > 50*time.Minute, fmt.Sprintf("before: %s, after: %s", m.FireAt, r.FireAt))
 	}
-	assert("test stream still exists after purge", false, "stream not found")
-}
 
-// ══════════════════════════════════════════════════════════════════
-// STREAM MESSAGES TESTS
-// ══════════════════════════════════════════════════════════════════
+	errMsg := queryExpectError(fmt.Sprintf(`mutation { recurringDelete(id: "%s") }`, id))
+	assert("recurringDelete rejects one-off message", errMsg != "", "expected error")
 
-func testStreamMessages() {
-	fmt.Println("\n── streamMessages ──")
+	data, err = query(fmt.Sprintf(`mutation { scheduledCancel(id: "%s") }`, id))
+	assert("cancel message", err == nil && unmarshal[bool](data, "scheduledCancel"), fmt.Sprint(err))
+	assert("gone from scheduledMessages", find(id) == nil, "still listed")
 
-	type msg struct {
-		Sequence  int    `json:"sequence"`
-		Subject   string `json:"subject"`
-		Data      string `json:"data"`
-		Published string `json:"published"`
-	}
+	errMsg = queryExpectError(fmt.Sprintf(`mutation { scheduledCancel(id: "%s") }`, id))
+	assert("cancel twice returns error", errMsg != "", "expected error")
+	errMsg = queryExpectError(`mutation { scheduledReschedule(id: "__no_such_schedule__", delay: 10) { id } }`)
+	assert("reschedule unknown ID returns error", errMsg != "", "expected error")
 
-	// Publish known messages for testing
-	for i := 1; i <= 5; i++ {
-		q := fmt.Sprintf(`mutation { publish(subject: "%s.read.%d", data: "msg-%d") { sequence } }`, testStream, i, i)
-		_, err := query(q)
+	// A cancelled message is never published
+	data, err = query(fmt.Sprintf(`{ streamLastMessage(stream: "%s", subject: "%s") { sequence } }`, testStream, subject))
+	assert("cancelled message not published", err == nil && string(data) == `{"streamLastMessage":null}`, fmt.Sprintf("got: %s %v", data, err))
+}
+
+func testPublishRecurring() {
+	fmt.Println("\n── publishRecurring ──")
+
+	type recurring struct {
+		ID       string `json:"id"`
+		Cron     string `json:"cron"`
+		Timezone string `json:"timezone"`
+		NextRun  string `json:"nextRun"`
+		Paused   bool   `json:"paused"`
+	}
+	const fields = `id cron timezone nextRun paused`
+	subject := testStream + ".tick"
+	count := func() int {
+		data, err := query(fmt.Sprintf(`{ streamMessages(stream: "%s", last: 100, subject: "%s") { sequence } }`, testStream, subject))
 		if err != nil {
-			assert(fmt.Sprintf("publish msg %d for read test", i), false, fmt.Sprint(err))
-			return
+			return -1
 		}
+		return len(unmarshal[[]struct{}](data, "streamMessages"))
 	}
-	assert("published 5 messages for read test", true, "")
 
-	// Read last 3 messages
-	q := fmt.Sprintf(`{ streamMessages(stream: "%s", last: 3) { sequence subject data published } }`, testStream)
-	data, err := query(q)
-	assert("read last 3 messages", err == nil, fmt.Sprint(err))
+	data, err := query(fmt.Sprintf(`mutation { publishRecurring(subject: "%s", data: "tick", cron: "@every 1s") { %s } }`, subject, fields))
+	assert("create recurring message", err == nil, fmt.Sprint(err))
 	if err != nil {
 		return
 	}
+	rm := unmarshal[recurring](data, "publishRecurring")
+	defer query(fmt.Sprintf(`mutation { recurringDelete(id: "%s") }`, rm.ID))
+	assert("id returned", rm.ID != "", "empty id")
+	assert("timezone defaults to UTC", rm.Timezone == "UTC", "got: "+rm.Timezone)
+	assert("nextRun set", rm.NextRun != "", "empty nextRun")
 
-	var result struct {
-		StreamMessages []msg `json:"streamMessages"`
+	data, err = query(`{ recurringMessages { id } scheduledMessages { id } }`)
+	assert("list recurring messages", err == nil, fmt.Sprint(err))
+	if err == nil {
+		listed := false
+		for _, m := range unmarshal[[]recurring](data, "recurringMessages") {
+			listed = listed || m.ID == rm.ID
+		}
+		assert("listed in recurringMessages", listed, "not found: "+rm.ID)
+		inScheduled := false
+		for _, m := range unmarshal[[]recurring](data, "scheduledMessages") {
+			inScheduled = inScheduled || m.ID == rm.ID
+		}
+		assert("not listed in scheduledMessages", !inScheduled, "found: "+rm.ID)
 	}
-	json.Unmarshal(data, &result)
-	assert("got 3 messages", len(result.StreamMessages) == 3, fmt.Sprintf("got: %d", len(result.StreamMessages)))
 
-	if len(result.StreamMessages) >= 3 {
-		// Check chronological order (oldest first)
-		assert("messages in order", result.StreamMessages[0].Sequence < result.StreamMessages[2].Sequence,
-			fmt.Sprintf("seq[0]=%d, seq[2]=%d", result.StreamMessages[0].Sequence, result.StreamMessages[2].Sequence))
+	time.Sleep(3500 * time.Millisecond)
+	n := count()
+	assert("published repeatedly", n >= 2, fmt.Sprintf("got: %d", n))
 
-		// Last message should be the most recent
-		lastMsg := result.StreamMessages[len(result.StreamMessages)-1]
-		assert("last message data is 'msg-5'", lastMsg.Data == "msg-5", "got: "+lastMsg.Data)
-		assert("subject contains stream prefix", strings.HasPrefix(lastMsg.Subject, testStream+"."), "got: "+lastMsg.Subject)
-		assert("published is set", lastMsg.Published != "", "empty published")
+	data, err = query(fmt.Sprintf(`mutation { recurringPause(id: "%s") { %s } }`, rm.ID, fields))
+	assert("pause recurring message", err == nil && unmarshal[recurring](data, "recurringPause").Paused, fmt.Sprint(err))
+	time.Sleep(500 * time.Millisecond)
+	paused := count()
+	time.Sleep(2 * time.Second)
+	after := count()
+	assert("nothing published while paused", after == paused, fmt.Sprintf("before: %d, after: %d", paused, after))
 
-		// Verify published is valid RFC3339
-		_, parseErr := time.Parse(time.RFC3339Nano, lastMsg.Published)
+	data, err = query(fmt.Sprintf(`mutation { recurringResume(id: "%s") { %s } }`, rm.ID, fields))
+	assert("resume recurring message", err == nil && !unmarshal[recurring](data, "recurringResume").Paused, fmt.Sprint(err))
+
+	errMsg := queryExpectError(fmt.Sprintf(`mutation { scheduledCancel(id: "%s") }`, rm.ID))
+	assert("scheduledCancel rejects recurring message", errMsg != "", "expected error")
+
+	data, err = query(fmt.Sprintf(`mutation { recurringDelete(id: "%s") }`, rm.ID))
+	assert("delete recurring message", err == nil && unmarshal[bool](data, "recurringDelete"), fmt.Sprint(err))
+
+	data, err = query(fmt.Sprintf(`mutation { publishRecurring(subject: "%s", data: "x", cron: "0 9 * * MON-FRI", timezone: "Europe/Berlin") { %s } }`, subject, fields))
+	assert("cron with timezone", err == nil, fmt.Sprint(err))
+	if err == nil {
+		tz := unmarshal[recurring](data, "publishRecurring")
+		query(fmt.Sprintf(`mutation { recurringDelete(id: "%s") }`, tz.ID))
+		next, _ := time.Parse(time.RFC3339, tz.NextRun)
+		loc, _ := time.LoadLocation("Europe/Berlin")
+		assert("nextRun at 09:00 local time", next.In(loc).Hour() == 9, "got: "+tz.NextRun)
+	}
+
+	errMsg = queryExpectError(fmt.Sprintf(`mutation { publishRecurring(subject: "%s", data: "x", cron: "not a cron") { id } }`, subject))
+	assert("invalid cron returns error", errMsg != "", "expected error")
+	errMsg = queryExpectError(fmt.Sprintf(`mutation { publishRecurring(subject: "%s", data: "x", cron: "@hourly", timezone: "Mars/Olympus") { id } }`, subject))
+	assert("invalid timezone returns error", errMsg != "", "expected error")
+	errMsg = queryExpectError(fmt.Sprintf(`mutation { publishRecurring(subject: "%s", data: "x", cron: "@hourly", timezone: "Local") { id } }`, subject))
+	assert("Local timezone returns error", errMsg != "", "expected error")
+	errMsg = queryExpectError(fmt.Sprintf(`mutation { publishRecurring(subject: "%s", data: "x", cron: "CRON_TZ=Asia/Tokyo 0 9 * * *") { id } }`, subject))
+	assert("CRON_TZ prefix returns error", errMsg != "", "expected error")
+	errMsg = queryExpectError(`mutation { publishRecurring(subject: "__no_stream_matches_this__.test", data: "x", cron: "@hourly") { id } }`)
+	assert("no matching stream returns error", errMsg != "", "expected error")
+}
+
+func testBinaryPayloads() {
+	fmt.Println("\n── binary payloads (encoding) ──")
+
+	// 0x00 0xff 0xfe 0x80 is not valid UTF-8
+	const b64 = "AP/+gA=="
+	const hexStr = "00fffe80"
+
+	q := fmt.Sprintf(`mutation { kvPut(bucket: "%s", key: "bin", value: "%s", encoding: BASE64) { value(encoding: HEX) } }`, testBucket, b64)
+	data, err := query(q)
+	assert("kvPut base64 value", err == nil, fmt.Sprint(err))
+	if err == nil {
+		e := unmarshal[map[string]string](data, "kvPut")
+		assert("kvPut returns hex value", e["value"] == hexStr, "got: "+e["value"])
+	}
+
+	data, err = query(fmt.Sprintf(`{ kvGet(bucket: "%s", key: "bin") { value(encoding: BASE64) } }`, testBucket))
+	assert("kvGet base64 value", err == nil, fmt.Sprint(err))
+	if err == nil {
+		e := unmarshal[map[string]string](data, "kvGet")
+		assert("kvGet round-trips bytes", e["value"] == b64, "got: "+e["value"])
+	}
+
+	subject := testStream + ".binary"
+	_, err = query(fmt.Sprintf(`mutation { publish(subject: "%s", data: "%s", encoding: HEX) { sequence } }`, subject, hexStr))
+	assert("publish hex payload", err == nil, fmt.Sprint(err))
+
+	data, err = query(fmt.Sprintf(`{ streamMessages(stream: "%s", last: 1, subject: "%s") { data(encoding: BASE64) } }`, testStream, subject))
+	assert("read binary message", err == nil, fmt.Sprint(err))
+	if err == nil {
+		msgs := unmarshal[[]map[string]string](data, "streamMessages")
+		assert("got 1 message", len(msgs) == 1, fmt.Sprintf("got: %d", len(msgs)))
+		if len(msgs) == 1 {
+			assert("message round-trips bytes", msgs[0]["data"] == b64, "got: "+msgs[0]["data"])
+		}
+	}
+
+	errMsg := queryExpectError(fmt.Sprintf(`mutation { publish(subject: "%s", data: "zz", encoding: HEX) { sequence } }`, subject))
+	assert("invalid hex returns error", errMsg != "", "expected error")
+}
+
+func testStreamDirectGet() {
+	fmt.Println("\n── streamMessage / streamLastMessage ──")
+
+	subject := testStream + ".direct"
+	var seqs []int
+	for _, d := range []string{"first", "second"} {
+		data, err := query(fmt.Sprintf(`mutation { publish(subject: "%s", data: "%s") { sequence } }`, subject, d))
+		if err != nil {
+			assert("publish message", false, fmt.Sprint(err))
+			return
+		}
+		seqs = append(seqs, unmarshal[map[string]int](data, "publish")["sequence"])
+	}
+
+	type msg struct {
+		Sequence int    `json:"sequence"`
+		Subject  string `json:"subject"`
+		Data     string `json:"data"`
+	}
+
+	data, err := query(fmt.Sprintf(`{ streamMessage(stream: "%s", seq: %d) { sequence subject data } }`, testStream, seqs[0]))
+	assert("get by sequence", err == nil, fmt.Sprint(err))
+	if err == nil {
+		m := unmarshal[msg](data, "streamMessage")
+		assert("sequence matches", m.Sequence == seqs[0], fmt.Sprintf("got: %d", m.Sequence))
+		assert("data matches", m.Data == "first", "got: "+m.Data)
+		assert("subject matches", m.Subject == subject, "got: "+m.Subject)
+	}
+
+	data, err = query(fmt.Sprintf(`{ streamLastMessage(stream: "%s", subject: "%s") { sequence data } }`, testStream, subject))
+	assert("get last for subject", err == nil, fmt.Sprint(err))
+	if err == nil {
+		m := unmarshal[msg](data, "streamLastMessage")
+		assert("last sequence matches", m.Sequence == seqs[1], fmt.Sprintf("got: %d", m.Sequence))
+		assert("last data matches", m.Data == "second", "got: "+m.Data)
+	}
+
+	data, err = query(fmt.Sprintf(`{ streamMessage(stream: "%s", seq: 999999) { sequence } }`, testStream))
+	assert("missing sequence query executes", err == nil, fmt.Sprint(err))
+	if err == nil {
+		assert("missing sequence returns null", string(data) == `{"streamMessage":null}`, "got: "+string(data))
+	}
+
+	data, err = query(fmt.Sprintf(`{ streamLastMessage(stream: "%s", subject: "%s.nothing") { sequence } }`, testStream, testStream))
+	assert("missing subject query executes", err == nil, fmt.Sprint(err))
+	if err == nil {
+		assert("missing subject returns null", string(data) == `{"streamLastMessage":null}`, "got: "+string(data))
+	}
+}
+
+func testStreamDeleteMessage() {
+	fmt.Println("\n── streamDeleteMessage ──")
+
+	subject := testStream + ".gdpr"
+	var seqs []int
+	for i := 0; i < 3; i++ {
+		data, err := query(fmt.Sprintf(`mutation { publish(subject: "%s", data: "msg-%d") { sequence } }`, subject, i))
+		if err != nil {
+			assert("publish message", false, fmt.Sprint(err))
+			return
+		}
+		seqs = append(seqs, unmarshal[map[string]int](data, "publish")["sequence"])
+	}
+
+	data, err := query(fmt.Sprintf(`mutation { streamDeleteMessage(stream: "%s", seq: %d) }`, testStream, seqs[0]))
+	assert("delete message", err == nil, fmt.Sprint(err))
+	if err == nil {
+		assert("delete returns true", unmarshal[bool](data, "streamDeleteMessage"), "expected true")
+	}
+
+	data, err = query(fmt.Sprintf(`mutation { streamDeleteMessage(stream: "%s", seq: %d, erase: true) }`, testStream, seqs[1]))
+	assert("secure erase message", err == nil, fmt.Sprint(err))
+	if err == nil {
+		assert("erase returns true", unmarshal[bool](data, "streamDeleteMessage"), "expected true")
+	}
+
+	data, _ = query(fmt.Sprintf(`{ streamMessage(stream: "%s", seq: %d) { sequence } }`, testStream, seqs[0]))
+	assert("deleted message is gone", string(data) == `{"streamMessage":null}`, "got: "+string(data))
+	data, _ = query(fmt.Sprintf(`{ streamMessage(stream: "%s", seq: %d) { sequence } }`, testStream, seqs[1]))
+	assert("erased message is gone", string(data) == `{"streamMessage":null}`, "got: "+string(data))
+	data, _ = query(fmt.Sprintf(`{ streamMessage(stream: "%s", seq: %d) { data } }`, testStream, seqs[2]))
+	assert("neighbour message is kept", strings.Contains(string(data), "msg-2"), "got: "+string(data))
+
+	errMsg := queryExpectError(fmt.Sprintf(`mutation { streamDeleteMessage(stream: "%s", seq: %d) }`, testStream, seqs[0]))
+	assert("deleting twice returns error", errMsg != "", "expected error")
+}
+
+// ══════════════════════════════════════════════════════════════════
+// STREAM PURGE TESTS
+// ══════════════════════════════════════════════════════════════════
+
+func testStreamPurge() {
+	fmt.Println("\n── streamPurge ──")
+
+	// Publish some messages first
+	for i := 1; i <= 3; i++ {
+		q := fmt.Sprintf(`mutation { publish(subject: "%s.purge.%d", data: "purge-msg-%d") { sequence } }`, testStream, i, i)
+		_, err := query(q)
+		if err != nil {
+			assert(fmt.Sprintf("publish msg %d for purge test", i), false, fmt.Sprint(err))
+			return
+		}
+	}
+	assert("published 3 messages for purge test", true, "")
+
+	// Verify messages exist
+	q := `{ streams { name messages } }`
+	data, err := query(q)
+	assert("query streams before purge", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+
+	type streamInfo struct {
+		Name     string `json:"name"`
+		Messages int    `json:"messages"`
+	}
+	var result struct {
+		Streams []streamInfo `json:"streams"`
+	}
+	json.Unmarshal(data, &result)
+
+	var msgsBefore int
+	for _, s := range result.Streams {
+		if s.Name == testStream {
+			msgsBefore = s.Messages
+		}
+	}
+	assert("messages > 0 before purge", msgsBefore > 0, fmt.Sprintf("got: %d", msgsBefore))
+
+	// Purge the stream
+	q = fmt.Sprintf(`mutation { streamPurge(name: "%s") }`, testStream)
+	data, err = query(q)
+	assert("purge stream", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+	purged := unmarshal[int](data, "streamPurge")
+	assert("returns purged count", purged == msgsBefore, fmt.Sprintf("got: %d, expected: %d", purged, msgsBefore))
+
+	// Verify messages are gone
+	q = `{ streams { name messages } }`
+	data, err = query(q)
+	assert("query streams after purge", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+	json.Unmarshal(data, &result)
+
+	for _, s := range result.Streams {
+		if s.Name == testStream {
+			assert("messages == 0 after purge", s.Messages == 0, fmt.Sprintf("got: %d", s.Messages))
+			return
+		}
+	}
+	assert("test stream still exists after purge", false, "stream not found")
+}
+
+func testStreamPurgeOptions() {
+	fmt.Println("\n── streamPurge (keep / upToSeq) ──")
+
+	subject := testStream + ".trim"
+	var seqs []int
+	for i := 0; i < 5; i++ {
+		data, err := query(fmt.Sprintf(`mutation { publish(subject: "%s", data: "trim-%d") { sequence } }`, subject, i))
+		if err != nil {
+			assert("publish message", false, fmt.Sprint(err))
+			return
+		}
+		seqs = append(seqs, unmarshal[map[string]int](data, "publish")["sequence"])
+	}
+
+	// Drop everything below the second message
+	data, err := query(fmt.Sprintf(`mutation { streamPurge(name: "%s", subject: "%s", upToSeq: %d) }`, testStream, subject, seqs[1]))
+	assert("purge up to sequence", err == nil, fmt.Sprint(err))
+	if err == nil {
+		purged := unmarshal[int](data, "streamPurge")
+		assert("purged 1 message", purged == 1, fmt.Sprintf("got: %d", purged))
+	}
+
+	// Keep only the newest 2 of the remaining 4
+	data, err = query(fmt.Sprintf(`mutation { streamPurge(name: "%s", subject: "%s", keep: 2) }`, testStream, subject))
+	assert("purge keeping newest", err == nil, fmt.Sprint(err))
+	if err == nil {
+		purged := unmarshal[int](data, "streamPurge")
+		assert("purged 2 messages", purged == 2, fmt.Sprintf("got: %d", purged))
+	}
+
+	data, _ = query(fmt.Sprintf(`{ streamMessage(stream: "%s", seq: %d) { data } }`, testStream, seqs[3]))
+	assert("newest messages are kept", strings.Contains(string(data), "trim-3"), "got: "+string(data))
+
+	errMsg := queryExpectError(fmt.Sprintf(`mutation { streamPurge(name: "%s", keep: 1, upToSeq: 10) }`, testStream))
+	assert("keep + upToSeq returns error", errMsg != "", "expected error")
+
+	errMsg = queryExpectError(`mutation { streamPurge(name: "__no_such_stream__") }`)
+	assert("missing stream returns error", errMsg != "", "expected error")
+}
+
+// ══════════════════════════════════════════════════════════════════
+// STREAM MESSAGES TESTS
+// ══════════════════════════════════════════════════════════════════
+
+func testStreamMessages() {
+	fmt.Println("\n── streamMessages ──")
+
+	type msg struct {
+		Sequence  int    `json:"sequence"`
+		Subject   string `json:"subject"`
+		Data      string `json:"data"`
+		Published string `json:"published"`
+	}
+
+	// Publish known messages for testing
+	for i := 1; i <= 5; i++ {
+		q := fmt.Sprintf(`mutation { publish(subject: "%s.read.%d", data: "msg-%d") { sequence } }`, testStream, i, i)
+		_, err := query(q)
+		if err != nil {
+			assert(fmt.Sprintf("publish msg %d for read test", i), false, fmt.Sprint(err))
+			return
+		}
+	}
+	assert("published 5 messages for read test", true, "")
+
+	// Read last 3 messages
+	q := fmt.Sprintf(`{ streamMessages(stream: "%s", last: 3) { sequence subject data published } }`, testStream)
+	data, err := query(q)
+	assert("read last 3 messages", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+
+	var result struct {
+		StreamMessages []msg `json:"streamMessages"`
+	}
+	json.Unmarshal(data, &result)
+	assert("got 3 messages", len(result.StreamMessages) == 3, fmt.Sprintf("got: %d", len(result.StreamMessages)))
+
+	if len(result.StreamMessages) >= 3 {
+		// Check chronological order (oldest first)
+		assert("messages in order", result.StreamMessages[0].Sequence < result.StreamMessages[2].Sequence,
+			fmt.Sprintf("seq[0]=%d, seq[2]=%d", result.StreamMessages[0].Sequence, result.StreamMessages[2].Sequence))
+
+		// Last message should be the most recent
+		lastMsg := result.StreamMessages[len(result.StreamMessages)-1]
+		assert("last message data is 'msg-5'", lastMsg.Data == "msg-5", "got: "+lastMsg.Data)
+		assert("subject contains stream prefix", strings.HasPrefix(lastMsg.Subject, testStream+"."), "got: "+lastMsg.Subject)
+		assert("published is set", lastMsg.Published != "", "empty published")
+
+		// Verify published is valid RFC3339
+		_, parseErr := time.Parse(time.RFC3339Nano, lastMsg.Published)
 		assert("published is valid RFC3339", parseErr == nil, fmt.Sprint(parseErr))
 	}
 
@@ -1223,43 +1927,803 @@ func testStreamMessagesFilterErrors() {
 	assert("startSeq=-1 returns error", errMsg != "", "expected error")
 }
 
-// ══════════════════════════════════════════════════════════════════
-// SETUP & TEARDOWN
-// ══════════════════════════════════════════════════════════════════
+func testStreamMessagesConnection() {
+	fmt.Println("\n── streamMessagesConnection ──")
+
+	type page struct {
+		Edges []struct {
+			Cursor string `json:"cursor"`
+			Node   struct {
+				Sequence int    `json:"sequence"`
+				Data     string `json:"data"`
+			} `json:"node"`
+		} `json:"edges"`
+		PageInfo struct {
+			HasNextPage     bool    `json:"hasNextPage"`
+			HasPreviousPage bool    `json:"hasPreviousPage"`
+			StartCursor     *string `json:"startCursor"`
+			EndCursor       *string `json:"endCursor"`
+		} `json:"pageInfo"`
+	}
+
+	// Interleave paged messages with noise on another subject
+	subject := testStream + ".page"
+	for i := 1; i <= 5; i++ {
+		_, err := query(fmt.Sprintf(`mutation { publish(subject: "%s", data: "page-%d") { sequence } }`, subject, i))
+		if err == nil {
+			_, err = query(fmt.Sprintf(`mutation { publish(subject: "%s.noise", data: "noise") { sequence } }`, testStream))
+		}
+		if err != nil {
+			assert("publish messages for paging", false, fmt.Sprint(err))
+			return
+		}
+	}
 
-func setup() {
-	natsURL := os.Getenv("NATS_URL")
-	if natsURL == "" {
-		natsURL = nats.DefaultURL
+	fields := `edges { cursor node { sequence data } } pageInfo { hasNextPage hasPreviousPage startCursor endCursor }`
+	fetch := func(args string) (page, error) {
+		data, err := query(fmt.Sprintf(`{ streamMessagesConnection(stream: "%s", subject: "%s"%s) { %s } }`, testStream, subject, args, fields))
+		if err != nil {
+			return page{}, err
+		}
+		return unmarshal[page](data, "streamMessagesConnection"), nil
+	}
+	datas := func(p page) string {
+		var out []string
+		for _, e := range p.Edges {
+			out = append(out, e.Node.Data)
+		}
+		return strings.Join(out, ",")
 	}
 
-	var err error
-	natsConn, err = nats.Connect(natsURL)
+	// Forward: first page
+	p1, err := fetch(", first: 2")
+	assert("first page", err == nil, fmt.Sprint(err))
 	if err != nil {
-		fmt.Printf("❌ Cannot connect to NATS at %s: %v\n", natsURL, err)
-		os.Exit(1)
+		return
+	}
+	assert("first page has 2 edges", datas(p1) == "page-1,page-2", "got: "+datas(p1))
+	assert("first page hasNextPage", p1.PageInfo.HasNextPage, "expected true")
+	assert("first page has no previous", !p1.PageInfo.HasPreviousPage, "expected false")
+	if p1.PageInfo.EndCursor == nil {
+		assert("endCursor is set", false, "nil endCursor")
+		return
 	}
 
-	js, err = jetstream.New(natsConn)
-	if err != nil {
-		fmt.Printf("❌ Cannot create JetStream context: %v\n", err)
-		os.Exit(1)
+	// Forward: following pages
+	p2, err := fetch(fmt.Sprintf(`, first: 2, after: "%s"`, *p1.PageInfo.EndCursor))
+	assert("second page", err == nil, fmt.Sprint(err))
+	assert("second page continues after cursor", datas(p2) == "page-3,page-4", "got: "+datas(p2))
+	assert("second page hasPreviousPage", p2.PageInfo.HasPreviousPage, "expected true")
+	assert("second page hasNextPage", p2.PageInfo.HasNextPage, "expected true")
+
+	if p2.PageInfo.EndCursor != nil {
+		p3, err := fetch(fmt.Sprintf(`, first: 2, after: "%s"`, *p2.PageInfo.EndCursor))
+		assert("last page", err == nil, fmt.Sprint(err))
+		assert("last page has remaining edge", datas(p3) == "page-5", "got: "+datas(p3))
+		assert("last page has no next", !p3.PageInfo.HasNextPage, "expected false")
+	}
+
+	// Backward: newest messages, then the page before them
+	b1, err := fetch(", last: 2")
+	assert("last 2 page", err == nil, fmt.Sprint(err))
+	assert("last page is oldest first", datas(b1) == "page-4,page-5", "got: "+datas(b1))
+	assert("last page hasPreviousPage", b1.PageInfo.HasPreviousPage, "expected true")
+	assert("last page without cursor has no next", !b1.PageInfo.HasNextPage, "expected false")
+
+	if b1.PageInfo.StartCursor != nil {
+		b2, err := fetch(fmt.Sprintf(`, last: 3, before: "%s"`, *b1.PageInfo.StartCursor))
+		assert("page before cursor", err == nil, fmt.Sprint(err))
+		assert("page before cursor edges", datas(b2) == "page-1,page-2,page-3", "got: "+datas(b2))
+		assert("page before cursor has no previous", !b2.PageInfo.HasPreviousPage, "expected false")
+		assert("page before cursor hasNextPage", b2.PageInfo.HasNextPage, "expected true")
+	}
+
+	// Backward without subject filter, over deleted sequences: g-3..g-5 are removed,
+	// so the window before g-6 holds no messages at all
+	var gapSeqs []int
+	for i := 1; i <= 6; i++ {
+		data, err := query(fmt.Sprintf(`mutation { publish(subject: "%s.gap", data: "g-%d") { sequence } }`, testStream, i))
+		if err != nil {
+			assert("publish messages for gap paging", false, fmt.Sprint(err))
+			return
+		}
+		gapSeqs = append(gapSeqs, unmarshal[map[string]int](data, "publish")["sequence"])
+	}
+	for _, seq := range gapSeqs[2:5] {
+		if _, err := query(fmt.Sprintf(`mutation { streamDeleteMessage(stream: "%s", seq: %d) }`, testStream, seq)); err != nil {
+			assert("delete messages for gap paging", false, fmt.Sprint(err))
+			return
+		}
 	}
+	fetchAll := func(args string) (page, error) {
+		data, err := query(fmt.Sprintf(`{ streamMessagesConnection(stream: "%s"%s) { %s } }`, testStream, args, fields))
+		if err != nil {
+			return page{}, err
+		}
+		return unmarshal[page](data, "streamMessagesConnection"), nil
+	}
+
+	g1, err := fetchAll(", last: 2")
+	assert("unfiltered last page", err == nil, fmt.Sprint(err))
+	assert("unfiltered last page skips deleted", datas(g1) == "g-2,g-6", "got: "+datas(g1))
+	assert("unfiltered last page hasPreviousPage", g1.PageInfo.HasPreviousPage, "expected true")
+
+	if g1.PageInfo.EndCursor != nil {
+		// The cursor of g-6, the two sequences before it are deleted
+		g2, err := fetchAll(fmt.Sprintf(`, last: 2, before: "%s"`, *g1.PageInfo.EndCursor))
+		assert("unfiltered page before deleted range", err == nil, fmt.Sprint(err))
+		assert("unfiltered page is full", datas(g2) == "g-1,g-2", "got: "+datas(g2))
+		assert("unfiltered page has startCursor", g2.PageInfo.StartCursor != nil, "nil startCursor")
+		assert("unfiltered page hasNextPage", g2.PageInfo.HasNextPage, "expected true")
+	}
+
+	// Argument validation
+	errMsg := queryExpectError(fmt.Sprintf(`{ streamMessagesConnection(stream: "%s", first: 101) { pageInfo { hasNextPage } } }`, testStream))
+	assert("first=101 returns error", errMsg != "", "expected error")
+	errMsg = queryExpectError(fmt.Sprintf(`{ streamMessagesConnection(stream: "%s", last: 1, after: "%s") { pageInfo { hasNextPage } } }`, testStream, *p1.PageInfo.EndCursor))
+	assert("last + after returns error", errMsg != "", "expected error")
+	errMsg = queryExpectError(fmt.Sprintf(`{ streamMessagesConnection(stream: "%s", after: "bogus") { pageInfo { hasNextPage } } }`, testStream))
+	assert("invalid cursor returns error", errMsg != "", "expected error")
+}
 
-	ctx := context.Background()
+// ══════════════════════════════════════════════════════════════════
+// CONSUMER TESTS
+// ══════════════════════════════════════════════════════════════════
 
-	// Create test KV bucket
-	_, err = js.CreateKeyValue(ctx, jetstream.KeyValueConfig{
-		Bucket: testBucket,
-	})
+func testConsumerFetchAndAck() {
+	fmt.Println("\n── consumerFetch / message acks ──")
+
+	type fetched struct {
+		Token         string `json:"token"`
+		DeliveryCount int    `json:"deliveryCount"`
+		Pending       int    `json:"pending"`
+		Message       struct {
+			Sequence int    `json:"sequence"`
+			Data     string `json:"data"`
+		} `json:"message"`
+	}
+
+	const consumer = "__test_worker__"
+	subject := testStream + ".work"
+	_, err := query(fmt.Sprintf(`mutation { consumerCreate(stream: "%s", name: "%s", filterSubject: "%s", deliverPolicy: "new") { name } }`,
+		testStream, consumer, subject))
+	assert("create pull consumer", err == nil, fmt.Sprint(err))
 	if err != nil {
-		fmt.Printf("❌ Cannot create test bucket: %v\n", err)
-		os.Exit(1)
+		return
 	}
-	fmt.Printf("   created test bucket '%s'\n", testBucket)
+	defer query(fmt.Sprintf(`mutation { consumerDelete(stream: "%s", name: "%s") }`, testStream, consumer))
 
-	// Create test stream
-	_, err = js.CreateStream(ctx, jetstream.StreamConfig{
+	for i := 1; i <= 3; i++ {
+		if _, err := query(fmt.Sprintf(`mutation { publish(subject: "%s", data: "job-%d") { sequence } }`, subject, i)); err != nil {
+			assert("publish jobs", false, fmt.Sprint(err))
+			return
+		}
+	}
+
+	fetch := func(batch, wait int) ([]fetched, error) {
+		data, err := query(fmt.Sprintf(`mutation { consumerFetch(stream: "%s", consumer: "%s", batch: %d, maxWait: %d) { token deliveryCount pending message { sequence data } } }`,
+			testStream, consumer, batch, wait))
+		if err != nil {
+			return nil, err
+		}
+		return unmarshal[[]fetched](data, "consumerFetch"), nil
+	}
+
+	msgs, err := fetch(3, 2)
+	assert("fetch batch", err == nil, fmt.Sprint(err))
+	if err != nil || len(msgs) != 3 {
+		assert("fetched 3 messages", false, fmt.Sprintf("got: %d", len(msgs)))
+		return
+	}
+	assert("fetched 3 messages", true, "")
+	assert("messages in order", msgs[0].Message.Data == "job-1" && msgs[2].Message.Data == "job-3",
+		fmt.Sprintf("got: %s, %s", msgs[0].Message.Data, msgs[2].Message.Data))
+	assert("first delivery count is 1", msgs[0].DeliveryCount == 1, fmt.Sprintf("got: %d", msgs[0].DeliveryCount))
+	assert("token is set", msgs[0].Token != "", "empty token")
+	assert("pending counts down", msgs[0].Pending == 2 && msgs[2].Pending == 0,
+		fmt.Sprintf("got: %d, %d", msgs[0].Pending, msgs[2].Pending))
+
+	data, err := query(fmt.Sprintf(`mutation { messageAck(token: "%s") }`, msgs[0].Token))
+	assert("ack message", err == nil && unmarshal[bool](data, "messageAck"), fmt.Sprint(err))
+	data, err = query(fmt.Sprintf(`mutation { messageTerm(token: "%s") }`, msgs[1].Token))
+	assert("term message", err == nil && unmarshal[bool](data, "messageTerm"), fmt.Sprint(err))
+	data, err = query(fmt.Sprintf(`mutation { messageNak(token: "%s") }`, msgs[2].Token))
+	assert("nak message", err == nil && unmarshal[bool](data, "messageNak"), fmt.Sprint(err))
+
+	// Only the nak'ed message comes back
+	again, err := fetch(3, 2)
+	assert("fetch after nak", err == nil, fmt.Sprint(err))
+	if len(again) == 1 {
+		assert("nak'ed message redelivered", again[0].Message.Data == "job-3", "got: "+again[0].Message.Data)
+		assert("delivery count is 2", again[0].DeliveryCount == 2, fmt.Sprintf("got: %d", again[0].DeliveryCount))
+
+		data, err = query(fmt.Sprintf(`mutation { messageInProgress(token: "%s") }`, again[0].Token))
+		assert("mark in progress", err == nil && unmarshal[bool](data, "messageInProgress"), fmt.Sprint(err))
+		data, err = query(fmt.Sprintf(`mutation { messageNak(token: "%s", delay: 30) }`, again[0].Token))
+		assert("nak with delay", err == nil && unmarshal[bool](data, "messageNak"), fmt.Sprint(err))
+	} else {
+		assert("nak'ed message redelivered", false, fmt.Sprintf("got %d messages", len(again)))
+	}
+
+	// Delayed message is not redelivered yet, acked/termed ones never
+	empty, err := fetch(3, 1)
+	assert("fetch with nothing available", err == nil, fmt.Sprint(err))
+	assert("no messages returned", len(empty) == 0, fmt.Sprintf("got: %d", len(empty)))
+
+	errMsg := queryExpectError(`mutation { messageAck(token: "not-a-token") }`)
+	assert("invalid token returns error", errMsg != "", "expected error")
+	errMsg = queryExpectError(fmt.Sprintf(`mutation { consumerFetch(stream: "%s", consumer: "%s", batch: 101) { token } }`, testStream, consumer))
+	assert("batch=101 returns error", errMsg != "", "expected error")
+	errMsg = queryExpectError(fmt.Sprintf(`mutation { consumerFetch(stream: "%s", consumer: "__no_such_consumer__") { token } }`, testStream))
+	assert("missing consumer returns error", errMsg != "", "expected error")
+}
+
+func testConsumerCreateOptions() {
+	fmt.Println("\n── consumerCreate advanced options ──")
+
+	type consumerInfo struct {
+		Backoff           []int `json:"backoff"`
+		InactiveThreshold *int  `json:"inactiveThreshold"`
+		MaxRequestBatch   int   `json:"maxRequestBatch"`
+		MaxRequestExpires int   `json:"maxRequestExpires"`
+		RateLimit         int   `json:"rateLimit"`
+		HeadersOnly       bool  `json:"headersOnly"`
+		MemoryStorage     bool  `json:"memoryStorage"`
+		Metadata          []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"metadata"`
+	}
+	const fields = `backoff inactiveThreshold maxRequestBatch maxRequestExpires rateLimit headersOnly memoryStorage metadata { key value }`
+
+	const consumer = "__test_options__"
+	data, err := query(fmt.Sprintf(`mutation { consumerCreate(stream: "%s", name: "%s", maxDeliver: 5, backoff: ["500ms", "5s", "10s"], inactiveThreshold: "1h", maxRequestBatch: 50, maxRequestExpires: "30s", headersOnly: true, memoryStorage: true, metadata: "{\"team\": \"billing\"}") { %s } }`,
+		testStream, consumer, fields))
+	assert("create consumer with options", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+	defer query(fmt.Sprintf(`mutation { consumerDelete(stream: "%s", name: "%s") }`, testStream, consumer))
+
+	ci := unmarshal[consumerInfo](data, "consumerCreate")
+	assert("backoff set", len(ci.Backoff) == 3 && ci.Backoff[0] == int(500*time.Millisecond) && ci.Backoff[2] == int(10*time.Second), fmt.Sprintf("got: %v", ci.Backoff))
+	assert("inactiveThreshold set", ci.InactiveThreshold != nil && *ci.InactiveThreshold == int(time.Hour), fmt.Sprintf("got: %v", ci.InactiveThreshold))
+	assert("maxRequestBatch set", ci.MaxRequestBatch == 50, fmt.Sprintf("got: %d", ci.MaxRequestBatch))
+	assert("maxRequestExpires set", ci.MaxRequestExpires == int(30*time.Second), fmt.Sprintf("got: %d", ci.MaxRequestExpires))
+	assert("rateLimit unset", ci.RateLimit == 0, fmt.Sprintf("got: %d", ci.RateLimit))
+	assert("headersOnly set", ci.HeadersOnly, "expected true")
+	assert("memoryStorage set", ci.MemoryStorage, "expected true")
+
+	// The server may add its own _nats.* entries next to user metadata
+	team := ""
+	for _, e := range ci.Metadata {
+		if e.Key == "team" {
+			team = e.Value
+		}
+	}
+	assert("metadata set", team == "billing", fmt.Sprintf("got: %v", ci.Metadata))
+
+	// Options are visible through consumerInfo as well
+	data, err = query(fmt.Sprintf(`{ consumerInfo(stream: "%s", name: "%s") { %s } }`, testStream, consumer, fields))
+	assert("consumerInfo with options", err == nil, fmt.Sprint(err))
+	if err == nil {
+		info := unmarshal[consumerInfo](data, "consumerInfo")
+		assert("consumerInfo reflects backoff", len(info.Backoff) == 3, fmt.Sprintf("got: %v", info.Backoff))
+		assert("consumerInfo reflects headersOnly", info.HeadersOnly, "expected true")
+	}
+
+	errMsg := queryExpectError(fmt.Sprintf(`mutation { consumerCreate(stream: "%s", name: "__test_bad_md__", metadata: "[1, 2]") { name } }`, testStream))
+	assert("invalid metadata returns error", errMsg != "", "expected error")
+	errMsg = queryExpectError(fmt.Sprintf(`mutation { consumerCreate(stream: "%s", name: "__test_bad_dur__", inactiveThreshold: "soon") { name } }`, testStream))
+	assert("invalid duration returns error", errMsg != "", "expected error")
+}
+
+func testConsumerUpdate() {
+	fmt.Println("\n── consumerUpdate ──")
+
+	type consumerInfo struct {
+		AckWait         int      `json:"ackWait"`
+		MaxDeliver      int      `json:"maxDeliver"`
+		MaxAckPending   int      `json:"maxAckPending"`
+		FilterSubjects  []string `json:"filterSubjects"`
+		Description     *string  `json:"description"`
+		DeliverPolicy   string   `json:"deliverPolicy"`
+		Backoff         []int    `json:"backoff"`
+		SampleFrequency *string  `json:"sampleFrequency"`
+	}
+	const fields = `ackWait maxDeliver maxAckPending filterSubjects description deliverPolicy backoff sampleFrequency`
+
+	const consumer = "__test_update__"
+	_, err := query(fmt.Sprintf(`mutation { consumerCreate(stream: "%s", name: "%s", deliverPolicy: "new", ackWait: 30, maxDeliver: 5, description: "before") { name } }`,
+		testStream, consumer))
+	assert("create consumer", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+	defer query(fmt.Sprintf(`mutation { consumerDelete(stream: "%s", name: "%s") }`, testStream, consumer))
+
+	// Only maxAckPending changes, everything else is kept
+	data, err := query(fmt.Sprintf(`mutation { consumerUpdate(stream: "%s", name: "%s", maxAckPending: 50) { %s } }`,
+		testStream, consumer, fields))
+	assert("partial update", err == nil, fmt.Sprint(err))
+	if err == nil {
+		ci := unmarshal[consumerInfo](data, "consumerUpdate")
+		assert("maxAckPending updated", ci.MaxAckPending == 50, fmt.Sprintf("got: %d", ci.MaxAckPending))
+		assert("maxDeliver kept", ci.MaxDeliver == 5, fmt.Sprintf("got: %d", ci.MaxDeliver))
+		assert("ackWait kept", ci.AckWait == int(30*time.Second), fmt.Sprintf("got: %d", ci.AckWait))
+		assert("description kept", ci.Description != nil && *ci.Description == "before", fmt.Sprintf("got: %v", ci.Description))
+		assert("deliverPolicy kept", ci.DeliverPolicy == "new", "got: "+ci.DeliverPolicy)
+		assert("no backoff", ci.Backoff == nil, fmt.Sprintf("got: %v", ci.Backoff))
+	}
+
+	data, err = query(fmt.Sprintf(`mutation { consumerUpdate(stream: "%s", name: "%s", ackWait: 10, maxDeliver: 4, filterSubjects: ["%s.a", "%s.b"], description: "after", backoff: ["1s", "5s", "10s"], sampleFrequency: "50%%") { %s } }`,
+		testStream, consumer, testStream, testStream, fields))
+	assert("update all fields", err == nil, fmt.Sprint(err))
+	if err == nil {
+		ci := unmarshal[consumerInfo](data, "consumerUpdate")
+		assert("maxDeliver updated", ci.MaxDeliver == 4, fmt.Sprintf("got: %d", ci.MaxDeliver))
+		assert("filterSubjects updated", len(ci.FilterSubjects) == 2, fmt.Sprintf("got: %v", ci.FilterSubjects))
+		assert("description updated", ci.Description != nil && *ci.Description == "after", fmt.Sprintf("got: %v", ci.Description))
+		assert("backoff updated", len(ci.Backoff) == 3 && ci.Backoff[1] == int(5*time.Second), fmt.Sprintf("got: %v", ci.Backoff))
+		assert("sampleFrequency updated", ci.SampleFrequency != nil && *ci.SampleFrequency == "50%", fmt.Sprintf("got: %v", ci.SampleFrequency))
+	}
+
+	// Empty backoff list clears the schedule
+	data, err = query(fmt.Sprintf(`mutation { consumerUpdate(stream: "%s", name: "%s", backoff: []) { %s } }`,
+		testStream, consumer, fields))
+	assert("clear backoff", err == nil, fmt.Sprint(err))
+	if err == nil {
+		ci := unmarshal[consumerInfo](data, "consumerUpdate")
+		assert("backoff cleared", ci.Backoff == nil, fmt.Sprintf("got: %v", ci.Backoff))
+		assert("filterSubjects kept", len(ci.FilterSubjects) == 2, fmt.Sprintf("got: %v", ci.FilterSubjects))
+	}
+
+	errMsg := queryExpectError(fmt.Sprintf(`mutation { consumerUpdate(stream: "%s", name: "__no_such_consumer__", maxDeliver: 3) { name } }`, testStream))
+	assert("missing consumer returns error", errMsg != "", "expected error")
+	errMsg = queryExpectError(fmt.Sprintf(`mutation { consumerUpdate(stream: "%s", name: "%s", maxDeliver: 2, backoff: ["1s", "2s", "3s"]) { name } }`, testStream, consumer))
+	assert("maxDeliver <= len(backoff) returns error", errMsg != "", "expected error")
+}
+
+// ══════════════════════════════════════════════════════════════════
+// CORE NATS TESTS
+// ══════════════════════════════════════════════════════════════════
+
+func testRequest() {
+	fmt.Println("\n── request ──")
+
+	type coreMessage struct {
+		Subject string `json:"subject"`
+		Data    string `json:"data"`
+		Headers []struct {
+			Key    string   `json:"key"`
+			Values []string `json:"values"`
+		} `json:"headers"`
+	}
+
+	// Echo service replying with the uppercased payload and the request's trace header
+	sub, err := natsConn.Subscribe("__test_e2e__.echo", func(m *nats.Msg) {
+		reply := nats.NewMsg(m.Reply)
+		reply.Data = []byte(strings.ToUpper(string(m.Data)))
+		reply.Header.Set("X-Trace-Id", m.Header.Get("X-Trace-Id"))
+		m.RespondMsg(reply)
+	})
+	assert("start echo responder", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+	defer sub.Unsubscribe()
+
+	// Responder that never answers
+	silent, _ := natsConn.Subscribe("__test_e2e__.silent", func(m *nats.Msg) {})
+	defer silent.Unsubscribe()
+	natsConn.Flush()
+
+	data, err := query(`mutation { request(subject: "__test_e2e__.echo", data: "ping", headers: "{\"X-Trace-Id\": \"t-1\"}") { subject data headers { key values } } }`)
+	assert("request returns reply", err == nil, fmt.Sprint(err))
+	if err == nil {
+		reply := unmarshal[coreMessage](data, "request")
+		assert("reply data", reply.Data == "PING", "got: "+reply.Data)
+		trace := ""
+		for _, h := range reply.Headers {
+			if h.Key == "X-Trace-Id" && len(h.Values) > 0 {
+				trace = h.Values[0]
+			}
+		}
+		assert("reply headers", trace == "t-1", fmt.Sprintf("got: %+v", reply.Headers))
+	}
+
+	data, err = query(`mutation { request(subject: "__test_e2e__.echo", data: "AAEC", encoding: BASE64) { data(encoding: BASE64) } }`)
+	assert("binary request", err == nil && unmarshal[coreMessage](data, "request").Data == "AAEC", fmt.Sprintf("got: %s %v", data, err))
+
+	errMsg := queryExpectError(`mutation { request(subject: "__test_e2e__.nobody", data: "x") { data } }`)
+	assert("no responders returns error", strings.Contains(errMsg, "no responders"), "got: "+errMsg)
+
+	start := time.Now()
+	errMsg = queryExpectError(`mutation { request(subject: "__test_e2e__.silent", data: "x", timeout: 1) { data } }`)
+	assert("timeout returns error", errMsg != "", "expected error")
+	assert("timeout honoured", time.Since(start) < 3*time.Second, fmt.Sprintf("took %s", time.Since(start)))
+
+	errMsg = queryExpectError(`mutation { request(subject: "__test_e2e__.echo", data: "x", timeout: 61) { data } }`)
+	assert("timeout=61 returns error", errMsg != "", "expected error")
+}
+
+func testCorePublish() {
+	fmt.Println("\n── corePublish ──")
+
+	sub, err := natsConn.SubscribeSync("__test_e2e__.core.>")
+	assert("subscribe to core subject", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+	defer sub.Unsubscribe()
+	natsConn.Flush()
+
+	data, err := query(`mutation { corePublish(subject: "__test_e2e__.core.temp", data: "21.5", headers: "{\"X-Sensor\": \"s-1\"}") }`)
+	assert("corePublish succeeds", err == nil, fmt.Sprint(err))
+	if err == nil {
+		assert("corePublish returns true", unmarshal[bool](data, "corePublish"), fmt.Sprintf("got: %s", data))
+	}
+
+	msg, err := sub.NextMsg(2 * time.Second)
+	assert("message delivered", err == nil, fmt.Sprint(err))
+	if err == nil {
+		assert("subject matches", msg.Subject == "__test_e2e__.core.temp", "got: "+msg.Subject)
+		assert("data matches", string(msg.Data) == "21.5", "got: "+string(msg.Data))
+		assert("header matches", msg.Header.Get("X-Sensor") == "s-1", fmt.Sprintf("got: %v", msg.Header))
+	}
+
+	_, err = query(`mutation { corePublish(subject: "__test_e2e__.core.bin", data: "AAEC", encoding: BASE64) }`)
+	assert("binary corePublish succeeds", err == nil, fmt.Sprint(err))
+	msg, err = sub.NextMsg(2 * time.Second)
+	assert("binary payload decoded", err == nil && string(msg.Data) == "\x00\x01\x02", fmt.Sprint(err))
+
+	errMsg := queryExpectError(`mutation { corePublish(subject: "__test_e2e__.core.bad", data: "!!", encoding: BASE64) }`)
+	assert("invalid base64 returns error", errMsg != "", "expected error")
+
+	errMsg = queryExpectError(`mutation { corePublish(subject: "__test_e2e__.core.bad", data: "x", headers: "not json") }`)
+	assert("invalid headers returns error", errMsg != "", "expected error")
+}
+
+func testServices() {
+	fmt.Println("\n── services ──")
+
+	type service struct {
+		Name      string `json:"name"`
+		ID        string `json:"id"`
+		Version   string `json:"version"`
+		Endpoints []struct {
+			Name     string `json:"name"`
+			Subject  string `json:"subject"`
+			Metadata []struct {
+				Key   string `json:"key"`
+				Value string `json:"value"`
+			} `json:"metadata"`
+		} `json:"endpoints"`
+	}
+	type serviceStats struct {
+		ID        string `json:"id"`
+		Endpoints []struct {
+			Name        string `json:"name"`
+			NumRequests int    `json:"numRequests"`
+			NumErrors   int    `json:"numErrors"`
+			LastError   string `json:"lastError"`
+		} `json:"endpoints"`
+	}
+
+	svc, err := micro.AddService(natsConn, micro.Config{
+		Name:        "e2e-text",
+		Version:     "1.0.0",
+		Description: "e2e test service",
+	})
+	assert("start micro service", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+	defer svc.Stop()
+
+	grp := svc.AddGroup("__test_e2e__.text")
+	grp.AddEndpoint("upper", micro.HandlerFunc(func(req micro.Request) {
+		req.Respond([]byte(strings.ToUpper(string(req.Data()))))
+	}), micro.WithEndpointMetadata(map[string]string{"schema": "string"}))
+	grp.AddEndpoint("fail", micro.HandlerFunc(func(req micro.Request) {
+		req.Error("400", "bad input", nil)
+	}))
+	natsConn.Flush()
+
+	data, err := query(`{ services(name: "e2e-text") { name id version endpoints { name subject metadata { key value } } } }`)
+	assert("services query", err == nil, fmt.Sprint(err))
+	if err == nil {
+		list := unmarshal[[]service](data, "services")
+		assert("one instance found", len(list) == 1, fmt.Sprintf("got %d", len(list)))
+		if len(list) == 1 {
+			assert("id matches", list[0].ID == svc.Info().ID, "got: "+list[0].ID)
+			assert("version matches", list[0].Version == "1.0.0", "got: "+list[0].Version)
+			assert("two endpoints", len(list[0].Endpoints) == 2, fmt.Sprintf("got %d", len(list[0].Endpoints)))
+			for _, ep := range list[0].Endpoints {
+				if ep.Name == "upper" {
+					assert("endpoint subject", ep.Subject == "__test_e2e__.text.upper", "got: "+ep.Subject)
+					assert("endpoint metadata", len(ep.Metadata) == 1 && ep.Metadata[0].Value == "string", fmt.Sprintf("got: %+v", ep.Metadata))
+				}
+			}
+		}
+	}
+
+	data, err = query(`{ services(name: "__e2e_missing__") { id } }`)
+	assert("unknown service returns empty list", err == nil && len(unmarshal[[]service](data, "services")) == 0, fmt.Sprint(err))
+
+	data, err = query(fmt.Sprintf(`{ servicePing(name: "e2e-text", id: "%s") { id } }`, svc.Info().ID))
+	assert("ping instance", err == nil && len(unmarshal[[]service](data, "servicePing")) == 1, fmt.Sprintf("got: %s %v", data, err))
+
+	errMsg := queryExpectError(`{ servicePing(id: "abc") { id } }`)
+	assert("id without name returns error", errMsg != "", "expected error")
+
+	errMsg = queryExpectError(`{ services(timeout: 11) { id } }`)
+	assert("timeout=11 returns error", errMsg != "", "expected error")
+
+	// ── serviceInvoke ──
+	data, err = query(`mutation { serviceInvoke(service: "e2e-text", endpoint: "upper", data: "hello") { subject data } }`)
+	assert("invoke endpoint", err == nil, fmt.Sprint(err))
+	if err == nil {
+		reply := unmarshal[struct {
+			Data string `json:"data"`
+		}](data, "serviceInvoke")
+		assert("reply data", reply.Data == "HELLO", "got: "+reply.Data)
+	}
+
+	code := queryExpectErrorCode(`mutation { serviceInvoke(service: "e2e-text", endpoint: "fail", data: "x") { data } }`)
+	assert("service error returns SERVICE_ERROR", code == "SERVICE_ERROR", "got: "+code)
+
+	errMsg = queryExpectError(`mutation { serviceInvoke(service: "e2e-text", endpoint: "nope", data: "x") { data } }`)
+	assert("unknown endpoint returns error", strings.Contains(errMsg, "not found"), "got: "+errMsg)
+
+	errMsg = queryExpectError(`mutation { serviceInvoke(service: "__e2e_missing__", endpoint: "upper", data: "x", timeout: 1) { data } }`)
+	assert("unknown service returns error", strings.Contains(errMsg, "not found"), "got: "+errMsg)
+
+	data, err = query(`{ serviceStats(name: "e2e-text") { id endpoints { name numRequests numErrors lastError } } }`)
+	assert("service stats", err == nil, fmt.Sprint(err))
+	if err == nil {
+		list := unmarshal[[]serviceStats](data, "serviceStats")
+		assert("stats for one instance", len(list) == 1, fmt.Sprintf("got %d", len(list)))
+		if len(list) == 1 {
+			for _, ep := range list[0].Endpoints {
+				switch ep.Name {
+				case "upper":
+					assert("upper counted one request", ep.NumRequests == 1, fmt.Sprintf("got %d", ep.NumRequests))
+				case "fail":
+					assert("fail counted one error", ep.NumErrors == 1 && ep.LastError != "", fmt.Sprintf("got %+v", ep))
+				}
+			}
+		}
+	}
+}
+
+// ══════════════════════════════════════════════════════════════════
+// SERVER MONITORING TESTS
+// ══════════════════════════════════════════════════════════════════
+
+func testServerMonitoring() {
+	fmt.Println("\n── server monitoring ──")
+
+	errMsg := queryExpectError(`{ serverInfo(timeout: 11) { serverId } }`)
+	assert("timeout=11 returns error", errMsg != "", "expected error")
+
+	errMsg = queryExpectError(`{ connections(limit: 1025) { serverId } }`)
+	assert("limit=1025 returns error", errMsg != "", "expected error")
+
+	// Monitoring needs system account credentials, which the test server may not grant
+	errMsg = queryExpectError(`{ serverInfo { serverId } }`)
+	if errMsg != "" {
+		assert("missing system account is reported", strings.Contains(errMsg, "system account"), "got: "+errMsg)
+		fmt.Println("  ⚠️  gateway is not connected to the system account, skipping monitoring checks")
+		return
+	}
+
+	type serverInfo struct {
+		ServerID    string `json:"serverId"`
+		ServerName  string `json:"serverName"`
+		Version     string `json:"version"`
+		Jetstream   bool   `json:"jetstream"`
+		Connections int    `json:"connections"`
+	}
+
+	data, err := query(`{ serverInfo { serverId serverName version jetstream connections } }`)
+	assert("serverInfo query", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+	servers := unmarshal[[]serverInfo](data, "serverInfo")
+	assert("at least one server", len(servers) > 0, "got none")
+	if len(servers) == 0 {
+		return
+	}
+	srv := servers[0]
+	assert("server has id and version", srv.ServerID != "" && srv.Version != "", fmt.Sprintf("got: %+v", srv))
+	assert("jetstream enabled", srv.Jetstream, "expected jetstream on test server")
+	assert("gateway connection counted", srv.Connections > 0, fmt.Sprintf("got: %d", srv.Connections))
+
+	data, err = query(fmt.Sprintf(`{ serverInfo(serverId: "%s") { serverId } }`, srv.ServerID))
+	assert("serverInfo by id", err == nil && len(unmarshal[[]serverInfo](data, "serverInfo")) == 1, fmt.Sprint(err))
+
+	type serverConnections struct {
+		Total       int `json:"total"`
+		Connections []struct {
+			Cid  int    `json:"cid"`
+			Lang string `json:"lang"`
+		} `json:"connections"`
+	}
+	data, err = query(`{ connections(sortBy: CID, limit: 1) { serverId total connections { cid lang } } }`)
+	assert("connections query", err == nil, fmt.Sprint(err))
+	if err == nil {
+		conns := unmarshal[[]serverConnections](data, "connections")
+		assert("connections per server", len(conns) == len(servers), fmt.Sprintf("got %d", len(conns)))
+		if len(conns) > 0 {
+			assert("limit applied", len(conns[0].Connections) <= 1, fmt.Sprintf("got %d", len(conns[0].Connections)))
+			assert("total counted", conns[0].Total > 0, fmt.Sprintf("got %d", conns[0].Total))
+		}
+	}
+
+	type jetstreamReport struct {
+		Enabled bool `json:"enabled"`
+		Streams int  `json:"streams"`
+	}
+	data, err = query(`{ jetstreamReport { serverId enabled streams api { total } } }`)
+	assert("jetstreamReport query", err == nil, fmt.Sprint(err))
+	if err == nil {
+		reports := unmarshal[[]jetstreamReport](data, "jetstreamReport")
+		assert("jetstream report enabled", len(reports) > 0 && reports[0].Enabled, fmt.Sprintf("got: %+v", reports))
+	}
+
+	type serverHealth struct {
+		Healthy bool   `json:"healthy"`
+		Status  string `json:"status"`
+	}
+	data, err = query(`{ clusterHealth { serverId healthy status error } }`)
+	assert("clusterHealth query", err == nil, fmt.Sprint(err))
+	if err == nil {
+		health := unmarshal[[]serverHealth](data, "clusterHealth")
+		assert("server is healthy", len(health) > 0 && health[0].Healthy && health[0].Status == "ok", fmt.Sprintf("got: %+v", health))
+	}
+
+	errMsg = queryExpectError(`{ clusterHealth(serverId: "NOSUCHSERVER") { serverId } }`)
+	assert("unknown server returns error", errMsg != "", "expected error")
+}
+
+// ══════════════════════════════════════════════════════════════════
+// OBJECT STORE TESTS
+// ══════════════════════════════════════════════════════════════════
+
+func testObjectStore() {
+	fmt.Println("\n── object store ──")
+
+	type objectInfo struct {
+		Name    string  `json:"name"`
+		Size    int     `json:"size"`
+		Chunks  int     `json:"chunks"`
+		Digest  *string `json:"digest"`
+		Headers []struct {
+			Key    string   `json:"key"`
+			Values []string `json:"values"`
+		} `json:"headers"`
+		Link *struct {
+			Bucket string  `json:"bucket"`
+			Name   *string `json:"name"`
+		} `json:"link"`
+	}
+	const fields = `name size chunks digest headers { key values } link { bucket name }`
+	const bucket = "__test_objects__"
+
+	data, err := query(fmt.Sprintf(`mutation { objectStoreCreate(bucket: "%s", description: "e2e", storage: "memory") { bucket description storage sealed } }`, bucket))
+	assert("create object store", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+	defer query(fmt.Sprintf(`mutation { objectStoreDelete(bucket: "%s") }`, bucket))
+
+	type store struct {
+		Bucket  string `json:"bucket"`
+		Storage string `json:"storage"`
+	}
+	created := unmarshal[store](data, "objectStoreCreate")
+	assert("bucket name returned", created.Bucket == bucket, "got: "+created.Bucket)
+	assert("memory storage", created.Storage == "Memory", "got: "+created.Storage)
+
+	data, err = query(`{ objectStores { bucket } }`)
+	listed := false
+	for _, st := range unmarshal[[]store](data, "objectStores") {
+		listed = listed || st.Bucket == bucket
+	}
+	assert("listed in objectStores", err == nil && listed, fmt.Sprint(err))
+
+	// Upload a multi-chunk object (default chunk size is 128KB)
+	blob := bytes.Repeat([]byte("0123456789abcdef"), 20000)
+	req, _ := http.NewRequest(http.MethodPut, baseURL+"/objects/"+bucket+"/docs/report.bin", bytes.NewReader(blob))
+	req.Header.Set("Content-Type", "application/x-test")
+	resp, err := http.DefaultClient.Do(req)
+	assert("upload object", err == nil && resp.StatusCode == http.StatusOK, fmt.Sprintf("err: %v", err))
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+
+	resp, body := httpGet("/objects/" + bucket + "/docs/report.bin")
+	assert("download object", resp != nil && resp.StatusCode == http.StatusOK, "bad response")
+	if resp != nil {
+		assert("downloaded data matches", body == string(blob), fmt.Sprintf("got %d bytes", len(body)))
+		assert("content type preserved", resp.Header.Get("Content-Type") == "application/x-test", "got: "+resp.Header.Get("Content-Type"))
+		assert("served as attachment", resp.Header.Get("Content-Disposition") == `attachment; filename=report.bin`, "got: "+resp.Header.Get("Content-Disposition"))
+		assert("nosniff set", resp.Header.Get("X-Content-Type-Options") == "nosniff", "got: "+resp.Header.Get("X-Content-Type-Options"))
+	}
+
+	data, err = query(fmt.Sprintf(`{ objectInfo(bucket: "%s", name: "docs/report.bin") { %s } }`, bucket, fields))
+	assert("objectInfo", err == nil, fmt.Sprint(err))
+	if err == nil {
+		info := unmarshal[objectInfo](data, "objectInfo")
+		assert("size reported", info.Size == len(blob), fmt.Sprintf("got: %d", info.Size))
+		assert("split into chunks", info.Chunks > 1, fmt.Sprintf("got: %d", info.Chunks))
+		assert("digest set", info.Digest != nil, "nil digest")
+	}
+
+	data, err = query(fmt.Sprintf(`mutation { objectLink(bucket: "%s", name: "latest", targetBucket: "%s", targetName: "docs/report.bin") { %s } }`, bucket, bucket, fields))
+	assert("create object link", err == nil, fmt.Sprint(err))
+	if err == nil {
+		link := unmarshal[objectInfo](data, "objectLink")
+		assert("link target reported", link.Link != nil && link.Link.Name != nil && *link.Link.Name == "docs/report.bin", fmt.Sprintf("got: %+v", link.Link))
+	}
+	resp, body = httpGet("/objects/" + bucket + "/latest")
+	assert("download through link", resp != nil && resp.StatusCode == http.StatusOK && len(body) == len(blob), fmt.Sprintf("got %d bytes", len(body)))
+
+	data, err = query(fmt.Sprintf(`{ objects(bucket: "%s") { name } }`, bucket))
+	assert("list objects", err == nil && len(unmarshal[[]objectInfo](data, "objects")) == 2, fmt.Sprintf("got: %s %v", data, err))
+
+	data, err = query(fmt.Sprintf(`mutation { objectDelete(bucket: "%s", name: "docs/report.bin") }`, bucket))
+	assert("delete object", err == nil && unmarshal[bool](data, "objectDelete"), fmt.Sprint(err))
+
+	data, err = query(fmt.Sprintf(`{ objectInfo(bucket: "%s", name: "docs/report.bin") { name } }`, bucket))
+	assert("deleted object returns null", err == nil && string(data) == `{"objectInfo":null}`, fmt.Sprintf("got: %s %v", data, err))
+
+	resp, _ = httpGet("/objects/" + bucket + "/docs/report.bin")
+	assert("download deleted object returns 404", resp != nil && resp.StatusCode == http.StatusNotFound, "expected 404")
+	resp, _ = httpGet("/objects/__no_such_bucket__/x")
+	assert("download from missing bucket returns 404", resp != nil && resp.StatusCode == http.StatusNotFound, "expected 404")
+}
+
+// ══════════════════════════════════════════════════════════════════
+// SETUP & TEARDOWN
+// ══════════════════════════════════════════════════════════════════
+
+func setup() {
+	natsURL := os.Getenv("NATS_URL")
+	if natsURL == "" {
+		natsURL = nats.DefaultURL
+	}
+
+	var err error
+	natsConn, err = nats.Connect(natsURL)
+	if err != nil {
+		fmt.Printf("❌ Cannot connect to NATS at %s: %v\n", natsURL, err)
+		os.Exit(1)
+	}
+
+	js, err = jetstream.New(natsConn)
+	if err != nil {
+		fmt.Printf("❌ Cannot create JetStream context: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+
+	// Create test KV bucket
+	_, err = js.CreateKeyValue(ctx, jetstream.KeyValueConfig{
+		Bucket: testBucket,
+	})
+	if err != nil {
+		fmt.Printf("❌ Cannot create test bucket: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("   created test bucket '%s'\n", testBucket)
+
+	// Create test stream
+	_, err = js.CreateStream(ctx, jetstream.StreamConfig{
 		Name:     testStream,
 		Subjects: []string{testStream + ".>"},
 	})
@@ -1296,37 +2760,6 @@ func teardown() {
 func testStreamSubscribe() {
 	fmt.Println("\n── streamSubscribe ──")
 
-	// Determine WebSocket URL from baseURL
-	wsURL := strings.Replace(baseURL, "http://", "ws://", 1)
-	wsURL = strings.Replace(wsURL, "https://", "wss://", 1)
-	wsURL += "/query"
-
-	// Helper: connect WebSocket with graphql-ws subprotocol
-	connectWS := func() (*websocket.Conn, error) {
-		dialer := websocket.Dialer{}
-		header := http.Header{}
-		header.Set("Sec-WebSocket-Protocol", "graphql-transport-ws")
-		conn, _, err := dialer.Dial(wsURL, header)
-		if err != nil {
-			return nil, err
-		}
-
-		// Send connection_init
-		init := map[string]any{"type": "connection_init"}
-		conn.WriteJSON(init)
-
-		// Wait for connection_ack
-		var ack map[string]any
-		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
-		conn.ReadJSON(&ack)
-		if ack["type"] != "connection_ack" {
-			conn.Close()
-			return nil, fmt.Errorf("expected connection_ack, got: %v", ack["type"])
-		}
-
-		return conn, nil
-	}
-
 	// ── Test 1: basic subscription ──
 	conn, err := connectWS()
 	assert("websocket connect", err == nil, fmt.Sprint(err))
@@ -1423,6 +2856,236 @@ func testStreamSubscribe() {
 			}
 		}
 	}
+
+	// ── Test 3: resume from a sequence ──
+	resumeSubject := testStream + ".resume"
+	var seqs []int
+	for _, d := range []string{"missed-1", "missed-2"} {
+		data, err := query(fmt.Sprintf(`mutation { publish(subject: "%s", data: "%s") { sequence } }`, resumeSubject, d))
+		if err != nil {
+			assert("publish while disconnected", false, fmt.Sprint(err))
+			return
+		}
+		seqs = append(seqs, unmarshal[struct {
+			Sequence int `json:"sequence"`
+		}](data, "publish").Sequence)
+	}
+
+	conn3, err := connectWS()
+	assert("ws connect for resume", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+	defer conn3.Close()
+
+	subscribeWS(conn3, "1", fmt.Sprintf(`subscription { streamSubscribe(stream: "%s", subject: "%s", startSeq: %d) { sequence data consumerReset } }`, testStream, resumeSubject, seqs[0]))
+	for i, want := range []string{"missed-1", "missed-2"} {
+		sm, err := readWSNext(conn3, "streamSubscribe")
+		assert(fmt.Sprintf("replayed message %d", i+1), err == nil, fmt.Sprint(err))
+		if err != nil {
+			break
+		}
+		assert(fmt.Sprintf("replayed data %d", i+1), sm["data"] == want, fmt.Sprintf("got: %v", sm["data"]))
+		assert(fmt.Sprintf("replayed sequence %d", i+1), sm["sequence"] == float64(seqs[i]), fmt.Sprintf("got: %v", sm["sequence"]))
+		assert("no consumer reset", sm["consumerReset"] == false, fmt.Sprintf("got: %v", sm["consumerReset"]))
+	}
+
+	// ── Test 4: latest message per subject ──
+	subscribeWS(conn3, "2", fmt.Sprintf(`subscription { streamSubscribe(stream: "%s", subject: "%s", deliverLastPerSubject: true) { data } }`, testStream, resumeSubject))
+	sm, err := readWSNext(conn3, "streamSubscribe")
+	assert("received last message per subject", err == nil, fmt.Sprint(err))
+	if err == nil {
+		assert("last message data", sm["data"] == "missed-2", fmt.Sprintf("got: %v", sm["data"]))
+	}
+
+	// ── Test 5: conflicting start positions ──
+	subscribeWS(conn3, "3", fmt.Sprintf(`subscription { streamSubscribe(stream: "%s", startSeq: 1, startTime: "2024-01-01T00:00:00Z") { data } }`, testStream))
+	conn3.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var msg3 map[string]any
+	err = conn3.ReadJSON(&msg3)
+	assert("conflicting start positions return error", err == nil && msg3["type"] == "error", fmt.Sprintf("got: %v %v", msg3, err))
+}
+
+func testKvWatch() {
+	fmt.Println("\n── kvWatch ──")
+
+	conn, err := connectWS()
+	assert("websocket connect", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	subscribeWS(conn, "1", fmt.Sprintf(`subscription { kvWatch(bucket: "%s", keys: ["watch.*"]) { key value revision operation } }`, testBucket))
+
+	// Give the watcher time to be created
+	time.Sleep(200 * time.Millisecond)
+
+	_, err = query(fmt.Sprintf(`mutation { kvPut(bucket: "%s", key: "watch.a", value: "v1") { revision } }`, testBucket))
+	assert("put watched key", err == nil, fmt.Sprint(err))
+
+	e, err := readWSNext(conn, "kvWatch")
+	assert("received put update", err == nil, fmt.Sprint(err))
+	if err == nil {
+		assert("key matches", e["key"] == "watch.a", fmt.Sprintf("got: %v", e["key"]))
+		assert("value matches", e["value"] == "v1", fmt.Sprintf("got: %v", e["value"]))
+		assert("operation is PUT", e["operation"] == "PUT", fmt.Sprintf("got: %v", e["operation"]))
+	}
+
+	// Keys outside the filter should not be delivered
+	query(fmt.Sprintf(`mutation { kvPut(bucket: "%s", key: "other", value: "skip") { revision } }`, testBucket))
+	query(fmt.Sprintf(`mutation { kvDelete(bucket: "%s", key: "watch.a") }`, testBucket))
+
+	e, err = readWSNext(conn, "kvWatch")
+	assert("received delete update", err == nil, fmt.Sprint(err))
+	if err == nil {
+		assert("deleted key matches", e["key"] == "watch.a", fmt.Sprintf("got: %v", e["key"]))
+		assert("operation is DEL", e["operation"] == "DEL", fmt.Sprintf("got: %v", e["operation"]))
+	}
+}
+
+func testSubjectSubscribe() {
+	fmt.Println("\n── subjectSubscribe ──")
+
+	conn, err := connectWS()
+	assert("websocket connect", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	subscribeWS(conn, "1", `subscription { subjectSubscribe(subject: "__test_e2e__.telemetry.>") { subject reply data headers { key values } } }`)
+
+	// Give the subscription time to be created
+	time.Sleep(200 * time.Millisecond)
+
+	_, err = query(`mutation { corePublish(subject: "__test_e2e__.telemetry.cpu", data: "42", headers: "{\"X-Host\": \"h-1\"}") }`)
+	assert("publish telemetry", err == nil, fmt.Sprint(err))
+
+	m, err := readWSNext(conn, "subjectSubscribe")
+	assert("received message", err == nil, fmt.Sprint(err))
+	if err == nil {
+		assert("subject matches", m["subject"] == "__test_e2e__.telemetry.cpu", fmt.Sprintf("got: %v", m["subject"]))
+		assert("data matches", m["data"] == "42", fmt.Sprintf("got: %v", m["data"]))
+		assert("reply is null", m["reply"] == nil, fmt.Sprintf("got: %v", m["reply"]))
+		headers, _ := m["headers"].([]any)
+		assert("headers present", len(headers) == 1, fmt.Sprintf("got: %v", m["headers"]))
+	}
+
+	// Subjects outside the wildcard should not be delivered
+	natsConn.Publish("__test_e2e__.other", []byte("skip"))
+	natsConn.Publish("__test_e2e__.telemetry.mem", []byte("7"))
+
+	m, err = readWSNext(conn, "subjectSubscribe")
+	assert("received second message", err == nil, fmt.Sprint(err))
+	if err == nil {
+		assert("filtered subject matches", m["subject"] == "__test_e2e__.telemetry.mem", fmt.Sprintf("got: %v", m["subject"]))
+	}
+
+	// Two members of the same queue group share the messages
+	qconn, err := connectWS()
+	assert("websocket connect (queue)", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+	defer qconn.Close()
+
+	q := `subscription { subjectSubscribe(subject: "__test_e2e__.jobs", queueGroup: "workers") { data } }`
+	subscribeWS(conn, "2", q)
+	subscribeWS(qconn, "1", q)
+	time.Sleep(200 * time.Millisecond)
+
+	natsConn.Publish("__test_e2e__.jobs", []byte("job-1"))
+	natsConn.Flush()
+
+	received := 0
+	for _, c := range []*websocket.Conn{conn, qconn} {
+		if _, err := readWSNext(c, "subjectSubscribe"); err == nil {
+			received++
+		}
+	}
+	assert("queue group delivers once", received == 1, fmt.Sprintf("received %d copies", received))
+}
+
+func testJetstreamEvents() {
+	fmt.Println("\n── jetstreamEvents ──")
+
+	const stream = "__test_events_e2e__"
+
+	conn, err := connectWS()
+	assert("websocket connect", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	subscribeWS(conn, "1", fmt.Sprintf(`subscription { jetstreamEvents(stream: "%s", types: [STREAM_CREATED, STREAM_DELETED, MESSAGE_TERMINATED]) {
+		type stream timestamp
+		... on MessageTerminatedEvent { consumer streamSeq deliveries }
+	} }`, stream))
+
+	// Give the subscription time to be created
+	time.Sleep(200 * time.Millisecond)
+
+	ctx := context.Background()
+	_, err = js.CreateStream(ctx, jetstream.StreamConfig{Name: stream, Subjects: []string{stream + ".>"}})
+	assert("create stream", err == nil, fmt.Sprint(err))
+	if err != nil {
+		return
+	}
+	defer js.DeleteStream(ctx, stream)
+
+	e, err := readWSNext(conn, "jetstreamEvents")
+	assert("received stream created event", err == nil, fmt.Sprint(err))
+	if err == nil {
+		assert("type is STREAM_CREATED", e["type"] == "STREAM_CREATED", fmt.Sprintf("got: %v", e["type"]))
+		assert("stream matches", e["stream"] == stream, fmt.Sprintf("got: %v", e["stream"]))
+		assert("timestamp is set", e["timestamp"] != "", "empty timestamp")
+	}
+
+	// Terminating a message emits a MESSAGE_TERMINATED advisory (consumer creation is filtered out)
+	_, err = query(fmt.Sprintf(`mutation { consumerCreate(stream: "%s", name: "worker") { name } }`, stream))
+	assert("create consumer", err == nil, fmt.Sprint(err))
+	js.Publish(ctx, stream+".job", []byte("poison"))
+
+	data, err := query(fmt.Sprintf(`mutation { consumerFetch(stream: "%s", consumer: "worker", batch: 1, maxWait: 2) { token } }`, stream))
+	assert("fetch message", err == nil, fmt.Sprint(err))
+	if err == nil {
+		fetched := unmarshal[[]struct {
+			Token string `json:"token"`
+		}](data, "consumerFetch")
+		if len(fetched) == 1 {
+			query(fmt.Sprintf(`mutation { messageTerm(token: "%s") }`, fetched[0].Token))
+		}
+	}
+
+	e, err = readWSNext(conn, "jetstreamEvents")
+	assert("received terminated event", err == nil, fmt.Sprint(err))
+	if err == nil {
+		assert("type is MESSAGE_TERMINATED", e["type"] == "MESSAGE_TERMINATED", fmt.Sprintf("got: %v", e["type"]))
+		assert("consumer matches", e["consumer"] == "worker", fmt.Sprintf("got: %v", e["consumer"]))
+		assert("stream sequence is 1", e["streamSeq"] == float64(1), fmt.Sprintf("got: %v", e["streamSeq"]))
+	}
+
+	err = js.DeleteStream(ctx, stream)
+	assert("delete stream", err == nil, fmt.Sprint(err))
+
+	e, err = readWSNext(conn, "jetstreamEvents")
+	assert("received stream deleted event", err == nil, fmt.Sprint(err))
+	if err == nil {
+		assert("type is STREAM_DELETED", e["type"] == "STREAM_DELETED", fmt.Sprintf("got: %v", e["type"]))
+	}
+
+	// Stream events cannot match a consumer filter
+	conn2, err := connectWS()
+	if err == nil {
+		defer conn2.Close()
+		subscribeWS(conn2, "1", `subscription { jetstreamEvents(consumer: "worker", types: [STREAM_CREATED]) { type } }`)
+		conn2.SetReadDeadline(time.Now().Add(5 * time.Second))
+		var msg map[string]any
+		err = conn2.ReadJSON(&msg)
+		assert("stream types with consumer filter returns error", err == nil && msg["type"] == "error", fmt.Sprintf("got: %v %v", msg, err))
+	}
 }
 
 // ══════════════════════════════════════════════════════════════════
@@ -1462,11 +3125,17 @@ func main() {
 	// ── Streams ──
 	testStreamsListAllFields()
 	testStreamsWithMessages()
+	testStreamByName()
+	testStreamSubjectCounts()
+	testAccountInfo()
 
 	// ── KV operations ──
 	testKvKeys()
 	testKvGet()
 	testKvDelete()
+	testKvCreateKeyAndUpdateKey()
+	testKvHistory()
+	testKvRevisionAndRevert()
 
 	// ── Error handling ──
 	testErrorNonexistentBucket()
@@ -1482,16 +3151,43 @@ func main() {
 	// ── Publish & StreamMessages ──
 	testPublish()
 	testPublishErrors()
+	testPublishScheduled()
+	testScheduledManagement()
+	testPublishRecurring()
+	testBinaryPayloads()
+	testStreamDirectGet()
+	testStreamDeleteMessage()
 	testStreamMessages()
 
 	// ── Stream Purge ──
 	testStreamPurge()
+	testStreamPurgeOptions()
 	testStreamMessagesEdgeCases()
 	testStreamMessagesFilters()
 	testStreamMessagesFilterErrors()
+	testStreamMessagesConnection()
+
+	// ── Consumers ──
+	testConsumerFetchAndAck()
+	testConsumerUpdate()
+	testConsumerCreateOptions()
+
+	// ── Core NATS ──
+	testRequest()
+	testCorePublish()
+	testServices()
+
+	// ── Server monitoring ──
+	testServerMonitoring()
+
+	// ── Object Store ──
+	testObjectStore()
 
 	// ── Subscriptions ──
 	testStreamSubscribe()
+	testKvWatch()
+	testSubjectSubscribe()
+	testJetstreamEvents()
 
 	// Summary
 	total := passed + failed
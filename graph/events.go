@@ -0,0 +1,175 @@
+package graph
+
+import (
+	"encoding/json"
+	"nats-graphql/graph/model"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// JetStream publishes advisories on $JS.EVENT.ADVISORY.<kind>.<stream>[.<consumer>]
+// and sampled metrics on $JS.EVENT.METRIC.<kind>.<stream>.<consumer>, in the
+// account that owns the stream.
+
+// jsEventSource describes where events of one type are published.
+type jsEventSource struct {
+	typ    model.JetStreamEventType
+	prefix string
+	// consumer events carry the consumer name as the last subject token
+	consumer bool
+}
+
+var jsEventSources = []jsEventSource{
+	{model.JetStreamEventTypeMaxDeliveries, "$JS.EVENT.ADVISORY.CONSUMER.MAX_DELIVERIES", true},
+	{model.JetStreamEventTypeMessageTerminated, "$JS.EVENT.ADVISORY.CONSUMER.MSG_TERMINATED", true},
+	{model.JetStreamEventTypeStreamCreated, "$JS.EVENT.ADVISORY.STREAM.CREATED", false},
+	{model.JetStreamEventTypeStreamDeleted, "$JS.EVENT.ADVISORY.STREAM.DELETED", false},
+	{model.JetStreamEventTypeStreamUpdated, "$JS.EVENT.ADVISORY.STREAM.UPDATED", false},
+	{model.JetStreamEventTypeConsumerCreated, "$JS.EVENT.ADVISORY.CONSUMER.CREATED", true},
+	{model.JetStreamEventTypeConsumerDeleted, "$JS.EVENT.ADVISORY.CONSUMER.DELETED", true},
+	{model.JetStreamEventTypeStreamLeaderElected, "$JS.EVENT.ADVISORY.STREAM.LEADER_ELECTED", false},
+	{model.JetStreamEventTypeConsumerLeaderElected, "$JS.EVENT.ADVISORY.CONSUMER.LEADER_ELECTED", true},
+	{model.JetStreamEventTypeAckLatency, "$JS.EVENT.METRIC.CONSUMER.ACK", true},
+}
+
+// subject returns the subscription subject for this source, narrowed to a
+// stream and consumer when set.
+func (src jsEventSource) subject(stream, consumer string) string {
+	if stream == "" {
+		stream = "*"
+	}
+	subject := src.prefix + "." + stream
+	if src.consumer {
+		if consumer == "" {
+			consumer = "*"
+		}
+		subject += "." + consumer
+	}
+	return subject
+}
+
+// jsEventSourcesFor returns the sources matching the requested types
+// (all if empty). Stream events are dropped when filtering by consumer.
+func jsEventSourcesFor(types []model.JetStreamEventType, consumer string) []jsEventSource {
+	var result []jsEventSource
+	for _, src := range jsEventSources {
+		if consumer != "" && !src.consumer {
+			continue
+		}
+		if len(types) > 0 && !containsEventType(types, src.typ) {
+			continue
+		}
+		result = append(result, src)
+	}
+	return result
+}
+
+func containsEventType(types []model.JetStreamEventType, typ model.JetStreamEventType) bool {
+	for _, t := range types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// jsEvent holds the fields of all supported advisories and metrics.
+type jsEvent struct {
+	ID          string     `json:"id"`
+	Timestamp   time.Time  `json:"timestamp"`
+	Stream      string     `json:"stream"`
+	Consumer    string     `json:"consumer"`
+	StreamSeq   uint64     `json:"stream_seq"`
+	ConsumerSeq uint64     `json:"consumer_seq"`
+	Deliveries  uint64     `json:"deliveries"`
+	Reason      string     `json:"reason"`
+	Leader      string     `json:"leader"`
+	Replicas    []peerInfo `json:"replicas"`
+	AckTime     uint64     `json:"ack_time"`
+}
+
+// mapJetStreamEvent converts an advisory or metric message to GraphQL model.
+// Returns nil if the message is not a supported event.
+func mapJetStreamEvent(msg *nats.Msg) model.JetStreamEvent {
+	var typ model.JetStreamEventType
+	for _, src := range jsEventSources {
+		if strings.HasPrefix(msg.Subject, src.prefix+".") {
+			typ = src.typ
+			break
+		}
+	}
+	if typ == "" {
+		return nil
+	}
+
+	var e jsEvent
+	if err := json.Unmarshal(msg.Data, &e); err != nil {
+		return nil
+	}
+	timestamp := e.Timestamp.Format(time.RFC3339Nano)
+
+	switch typ {
+	case model.JetStreamEventTypeMaxDeliveries:
+		return &model.MaxDeliveriesEvent{
+			Type:       typ,
+			ID:         e.ID,
+			Timestamp:  timestamp,
+			Stream:     e.Stream,
+			Consumer:   e.Consumer,
+			StreamSeq:  int(e.StreamSeq),
+			Deliveries: int(e.Deliveries),
+		}
+	case model.JetStreamEventTypeMessageTerminated:
+		return &model.MessageTerminatedEvent{
+			Type:        typ,
+			ID:          e.ID,
+			Timestamp:   timestamp,
+			Stream:      e.Stream,
+			Consumer:    e.Consumer,
+			StreamSeq:   int(e.StreamSeq),
+			ConsumerSeq: int(e.ConsumerSeq),
+			Deliveries:  int(e.Deliveries),
+			Reason:      optionalString(e.Reason),
+		}
+	case model.JetStreamEventTypeStreamCreated, model.JetStreamEventTypeStreamDeleted, model.JetStreamEventTypeStreamUpdated:
+		return &model.StreamActionEvent{
+			Type:      typ,
+			ID:        e.ID,
+			Timestamp: timestamp,
+			Stream:    e.Stream,
+		}
+	case model.JetStreamEventTypeConsumerCreated, model.JetStreamEventTypeConsumerDeleted:
+		return &model.ConsumerActionEvent{
+			Type:      typ,
+			ID:        e.ID,
+			Timestamp: timestamp,
+			Stream:    e.Stream,
+			Consumer:  e.Consumer,
+		}
+	case model.JetStreamEventTypeStreamLeaderElected, model.JetStreamEventTypeConsumerLeaderElected:
+		return &model.LeaderElectedEvent{
+			Type:      typ,
+			ID:        e.ID,
+			Timestamp: timestamp,
+			Stream:    e.Stream,
+			Consumer:  optionalString(e.Consumer),
+			Leader:    e.Leader,
+			Replicas:  mapPeers(e.Replicas),
+		}
+	case model.JetStreamEventTypeAckLatency:
+		return &model.AckLatencyEvent{
+			Type:        typ,
+			ID:          e.ID,
+			Timestamp:   timestamp,
+			Stream:      e.Stream,
+			Consumer:    e.Consumer,
+			StreamSeq:   int(e.StreamSeq),
+			ConsumerSeq: int(e.ConsumerSeq),
+			Deliveries:  int(e.Deliveries),
+			AckTime:     int(e.AckTime),
+		}
+	}
+	return nil
+}
@@ -1,16 +1,299 @@
 package graph
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"nats-graphql/graph/model"
+	"nats-graphql/scheduler"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
+	"github.com/nats-io/nats.go/micro"
+	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
+// Error codes returned in the "extensions.code" field of GraphQL errors,
+// so clients can react to specific failures without parsing messages.
+const (
+	errCodeKeyExists        = "KEY_EXISTS"
+	errCodeRevisionMismatch = "REVISION_MISMATCH"
+	errCodeServiceError     = "SERVICE_ERROR"
+)
+
+// codedError wraps err into a GraphQL error carrying the given code in its extensions.
+func codedError(code string, err error) error {
+	return &gqlerror.Error{
+		Err:     err,
+		Message: err.Error(),
+		Extensions: map[string]interface{}{
+			"code": code,
+		},
+	}
+}
+
+// jsAPIRequest sends a JSON request to a JetStream API endpoint (e.g. "STREAM.PURGE.orders")
+// and decodes the reply into resp. Used where the jetstream package hides parts of the response.
+func (r *Resolver) jsAPIRequest(ctx context.Context, subject string, req, resp interface{}) error {
+	prefix := r.JS.Options().APIPrefix
+	if prefix == "" {
+		prefix = jetstream.DefaultAPIPrefix
+	}
+	if !strings.HasSuffix(prefix, ".") {
+		prefix += "."
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	msg, err := r.NC.RequestWithContext(ctx, prefix+subject, body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(msg.Data, resp)
+}
+
+// streamSubjects returns the message count per subject matching filter. It asks
+// STREAM.INFO directly, since looking the stream up first (JS.Stream) would cost
+// a second round trip per stream. The server pages large subject lists.
+func (r *Resolver) streamSubjects(ctx context.Context, stream, filter string) (map[string]uint64, error) {
+	type request struct {
+		SubjectsFilter string `json:"subjects_filter"`
+		Offset         int    `json:"offset,omitempty"`
+	}
+	counts := make(map[string]uint64)
+	for {
+		var resp struct {
+			Error *jetstream.APIError `json:"error,omitempty"`
+			State struct {
+				Subjects map[string]uint64 `json:"subjects"`
+			} `json:"state"`
+			Total int `json:"total"`
+		}
+		req := request{SubjectsFilter: filter, Offset: len(counts)}
+		if err := r.jsAPIRequest(ctx, "STREAM.INFO."+stream, req, &resp); err != nil {
+			return nil, err
+		}
+		if resp.Error != nil {
+			return nil, resp.Error
+		}
+		for subj, n := range resp.State.Subjects {
+			counts[subj] = n
+		}
+		if len(resp.State.Subjects) == 0 || len(counts) >= resp.Total {
+			return counts, nil
+		}
+	}
+}
+
+// gatherReplies broadcasts a request on subject and collects every reply that
+// arrives within wait, or until limit replies arrived (0 = no limit). Used for
+// discovery subjects answered by many responders, where the number of replies
+// is not known in advance.
+func (r *Resolver) gatherReplies(ctx context.Context, subject string, data []byte, wait time.Duration, limit int) ([]*nats.Msg, error) {
+	inbox := r.NC.NewInbox()
+	sub, err := r.NC.SubscribeSync(inbox)
+	if err != nil {
+		return nil, err
+	}
+	defer sub.Unsubscribe()
+
+	if err := r.NC.PublishRequest(subject, inbox, data); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+
+	var replies []*nats.Msg
+	for {
+		msg, err := sub.NextMsgWithContext(ctx)
+		if errors.Is(err, context.DeadlineExceeded) {
+			return replies, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		// The server answers with a 503 status when nobody listens on the subject
+		if len(msg.Data) == 0 && msg.Header.Get("Status") == "503" {
+			return replies, nil
+		}
+		replies = append(replies, msg)
+		if limit > 0 && len(replies) >= limit {
+			return replies, nil
+		}
+	}
+}
+
+// discoveryWait validates the timeout (in seconds) of a discovery request.
+func discoveryWait(timeout *int) (time.Duration, error) {
+	const maxTimeout = 10
+	wait := 1
+	if timeout != nil {
+		wait = *timeout
+	}
+	if wait <= 0 || wait > maxTimeout {
+		return 0, fmt.Errorf("timeout must be between 1 and %d seconds", maxTimeout)
+	}
+	return time.Duration(wait) * time.Second, nil
+}
+
+// serviceDiscovery sends a micro service discovery request ($SRV.PING / INFO / STATS),
+// optionally narrowed to a service name and instance id, and returns the raw replies.
+func (r *Resolver) serviceDiscovery(ctx context.Context, verb micro.Verb, name, id *string, timeout *int) ([]*nats.Msg, error) {
+	wait, err := discoveryWait(timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	var n, i string
+	if name != nil {
+		n = *name
+	}
+	if id != nil {
+		i = *id
+	}
+	subject, err := micro.ControlSubject(verb, n, i)
+	if err != nil {
+		return nil, err
+	}
+
+	// A single instance answers at most once, no need to wait for the timeout
+	limit := 0
+	if i != "" {
+		limit = 1
+	}
+	return r.gatherReplies(ctx, subject, nil, wait, limit)
+}
+
+// requireScheduler returns the scheduler, or an error if it failed to start
+// (e.g. the credentials do not allow creating its KV bucket).
+func (r *Resolver) requireScheduler() (*scheduler.Scheduler, error) {
+	if r.Scheduler == nil {
+		return nil, errors.New("scheduler unavailable: the schedule bucket could not be opened, check the server logs")
+	}
+	return r.Scheduler, nil
+}
+
+// maxPayload is the largest decoded payload accepted for publishing.
+const maxPayload = 1 << 20 // 1 MB
+
+// buildMessage decodes and validates the payload and headers of a message to publish.
+func buildMessage(subject, data string, headers *string, encoding *model.Encoding) (*nats.Msg, error) {
+	payload, err := decodePayload(data, encoding)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) > maxPayload {
+		return nil, fmt.Errorf("payload too large: %d bytes (max %d)", len(payload), maxPayload)
+	}
+
+	msg := &nats.Msg{
+		Subject: subject,
+		Data:    payload,
+	}
+
+	if headers != nil && *headers != "" {
+		h, err := parseHeaders(*headers)
+		if err != nil {
+			return nil, err
+		}
+		msg.Header = h
+	}
+
+	return msg, nil
+}
+
+// requestWait validates the timeout (in seconds) to wait for a reply.
+func requestWait(timeout *int) (time.Duration, error) {
+	const maxTimeout = 60
+	wait := 5
+	if timeout != nil {
+		wait = *timeout
+	}
+	if wait <= 0 || wait > maxTimeout {
+		return 0, fmt.Errorf("timeout must be between 1 and %d seconds", maxTimeout)
+	}
+	return time.Duration(wait) * time.Second, nil
+}
+
+// prepareScheduled validates a message for publishScheduled / publishRecurring
+// and returns its decoded payload and headers.
+func (r *Resolver) prepareScheduled(ctx context.Context, subject, data string, headers *string, encoding *model.Encoding) ([]byte, nats.Header, error) {
+	msg, err := buildMessage(subject, data, headers, encoding)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Scheduled messages are published through JetStream, so fail early if nothing would store them
+	if _, err := r.JS.StreamNameBySubject(ctx, subject); err != nil {
+		return nil, nil, fmt.Errorf("no stream found for subject %q: %w", subject, err)
+	}
+
+	return msg.Data, msg.Header, nil
+}
+
+// encodeAckToken builds an opaque ack token from a message's reply subject.
+func encodeAckToken(reply string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(reply))
+}
+
+// decodeAckToken extracts the ack reply subject ($JS.ACK...) from a token.
+// The subject must have the layout the server uses for ack replies: 9 tokens,
+// or 11 and more when it carries a domain and account hash (JetStream v2).
+func decodeAckToken(token string) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	reply := string(raw)
+	if err != nil || !strings.HasPrefix(reply, "$JS.ACK.") {
+		return "", fmt.Errorf("invalid ack token")
+	}
+	tokens := strings.Split(reply, ".")
+	if len(tokens) != 9 && len(tokens) < 11 {
+		return "", fmt.Errorf("invalid ack token")
+	}
+	for _, t := range tokens {
+		if t == "" || t == "*" || t == ">" || strings.ContainsAny(t, " \t\r\n") {
+			return "", fmt.Errorf("invalid ack token")
+		}
+	}
+	return reply, nil
+}
+
+// ackMessage sends an acknowledgement (e.g. "+ACK", "-NAK") for a fetched message and
+// waits for the server to confirm it, so a gone consumer surfaces as an error.
+func (r *Resolver) ackMessage(ctx context.Context, token string, payload []byte) (bool, error) {
+	reply, err := decodeAckToken(token)
+	if err != nil {
+		return false, err
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+	}
+
+	if _, err := r.NC.RequestWithContext(ctx, reply, payload); err != nil {
+		return false, fmt.Errorf("failed to acknowledge message: %w", err)
+	}
+	return true, nil
+}
+
 // parseHeaders parses a JSON string into nats.Header.
 // Accepts {"key": "value"} or {"key": ["v1", "v2"]} format.
 func parseHeaders(jsonStr string) (nats.Header, error) {
@@ -39,6 +322,73 @@ func parseHeaders(jsonStr string) (nats.Header, error) {
 	return h, nil
 }
 
+// decodePayload converts a GraphQL string argument into raw bytes.
+// A nil encoding means UTF8 (the string is used as-is).
+func decodePayload(s string, encoding *model.Encoding) ([]byte, error) {
+	if encoding == nil {
+		return []byte(s), nil
+	}
+	switch *encoding {
+	case model.EncodingBase64:
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 payload: %w", err)
+		}
+		return b, nil
+	case model.EncodingHex:
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex payload: %w", err)
+		}
+		return b, nil
+	default:
+		return []byte(s), nil
+	}
+}
+
+// encodePayload converts raw bytes into a GraphQL string.
+// A nil encoding means UTF8 (bytes are returned as a string).
+func encodePayload(b []byte, encoding *model.Encoding) string {
+	if encoding == nil {
+		return string(b)
+	}
+	switch *encoding {
+	case model.EncodingBase64:
+		return base64.StdEncoding.EncodeToString(b)
+	case model.EncodingHex:
+		return hex.EncodeToString(b)
+	default:
+		return string(b)
+	}
+}
+
+// parseMetadata parses a JSON object of string values into consumer metadata.
+func parseMetadata(jsonStr string) (map[string]string, error) {
+	var md map[string]string
+	if err := json.Unmarshal([]byte(jsonStr), &md); err != nil {
+		return nil, fmt.Errorf("invalid metadata JSON (expected an object of strings): %w", err)
+	}
+	return md, nil
+}
+
+// mapMetadata converts consumer metadata to GraphQL model, sorted by key.
+func mapMetadata(md map[string]string) []*model.MetadataEntry {
+	if len(md) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(md))
+	for k := range md {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	result := make([]*model.MetadataEntry, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, &model.MetadataEntry{Key: k, Value: md[k]})
+	}
+	return result
+}
+
 // mapHeaders converts nats.Header to GraphQL HeaderEntry slice.
 // Returns nil if there are no headers (so the field is null in the response).
 func mapHeaders(h nats.Header) []*model.HeaderEntry {
@@ -72,23 +422,166 @@ func parseRFC3339(s string) (time.Time, error) {
 	return time.Parse(time.RFC3339, s)
 }
 
+// parseDuration parses a duration argument in Go syntax (e.g. "500ms", "1m30s").
+func parseDuration(name, s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%s: invalid duration %q, expected e.g. \"500ms\" or \"1m\"", name, s)
+	}
+	return d, nil
+}
+
+// parseBackoff parses a redelivery backoff schedule.
+func parseBackoff(delays []string) ([]time.Duration, error) {
+	result := make([]time.Duration, len(delays))
+	for i, s := range delays {
+		d, err := parseDuration("backoff", s)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = d
+	}
+	return result, nil
+}
+
+// mapCoreMessage converts a core NATS message to GraphQL model.
+func mapCoreMessage(msg *nats.Msg) *model.CoreMessage {
+	result := &model.CoreMessage{
+		Subject: msg.Subject,
+		Data:    string(msg.Data),
+		Headers: mapHeaders(msg.Header),
+	}
+	if msg.Reply != "" {
+		reply := msg.Reply
+		result.Reply = &reply
+	}
+	return result
+}
+
+// mapService converts a micro service INFO reply to GraphQL model.
+func mapService(info *micro.Info) *model.Service {
+	result := &model.Service{
+		Name:      info.Name,
+		ID:        info.ID,
+		Version:   info.Version,
+		Metadata:  mapMetadata(info.Metadata),
+		Endpoints: make([]*model.ServiceEndpoint, 0, len(info.Endpoints)),
+	}
+	if info.Description != "" {
+		desc := info.Description
+		result.Description = &desc
+	}
+	for _, ep := range info.Endpoints {
+		endpoint := &model.ServiceEndpoint{
+			Name:     ep.Name,
+			Subject:  ep.Subject,
+			Metadata: mapMetadata(ep.Metadata),
+		}
+		if ep.QueueGroup != "" {
+			qg := ep.QueueGroup
+			endpoint.QueueGroup = &qg
+		}
+		result.Endpoints = append(result.Endpoints, endpoint)
+	}
+	return result
+}
+
+// mapServiceStats converts a micro service STATS reply to GraphQL model.
+func mapServiceStats(stats *micro.Stats) *model.ServiceStats {
+	result := &model.ServiceStats{
+		Name:      stats.Name,
+		ID:        stats.ID,
+		Version:   stats.Version,
+		Started:   stats.Started.Format(time.RFC3339),
+		Endpoints: make([]*model.ServiceEndpointStats, 0, len(stats.Endpoints)),
+	}
+	for _, ep := range stats.Endpoints {
+		endpoint := &model.ServiceEndpointStats{
+			Name:                  ep.Name,
+			Subject:               ep.Subject,
+			NumRequests:           ep.NumRequests,
+			NumErrors:             ep.NumErrors,
+			ProcessingTime:        int(ep.ProcessingTime),
+			AverageProcessingTime: int(ep.AverageProcessingTime),
+		}
+		if ep.QueueGroup != "" {
+			qg := ep.QueueGroup
+			endpoint.QueueGroup = &qg
+		}
+		if ep.LastError != "" {
+			lastErr := ep.LastError
+			endpoint.LastError = &lastErr
+		}
+		if len(ep.Data) > 0 && string(ep.Data) != "null" {
+			data := string(ep.Data)
+			endpoint.Data = &data
+		}
+		result.Endpoints = append(result.Endpoints, endpoint)
+	}
+	return result
+}
+
+// mapRawStreamMsg converts a message read by direct get to GraphQL model.
+func mapRawStreamMsg(msg *jetstream.RawStreamMsg) *model.StreamMessage {
+	return &model.StreamMessage{
+		Sequence:  int(msg.Sequence),
+		Subject:   msg.Subject,
+		Data:      string(msg.Data),
+		Published: msg.Time.Format(time.RFC3339Nano),
+		Headers:   mapHeaders(msg.Header),
+	}
+}
+
+// mapScheduledMessage converts a pending scheduled publish to GraphQL model.
+func mapScheduledMessage(msg *scheduler.Message) *model.ScheduledMessage {
+	return &model.ScheduledMessage{
+		ID:      msg.ID,
+		Subject: msg.Subject,
+		Data:    string(msg.Data),
+		Headers: mapHeaders(msg.Header),
+		FireAt:  msg.FireAt.Format(time.RFC3339),
+		Created: msg.Created.Format(time.RFC3339),
+	}
+}
+
+// mapRecurringMessage converts a recurring scheduled publish to GraphQL model.
+func mapRecurringMessage(msg *scheduler.Message) *model.RecurringMessage {
+	return &model.RecurringMessage{
+		ID:       msg.ID,
+		Subject:  msg.Subject,
+		Data:     string(msg.Data),
+		Headers:  mapHeaders(msg.Header),
+		Cron:     msg.Cron,
+		Timezone: msg.Timezone,
+		NextRun:  msg.FireAt.Format(time.RFC3339),
+		Paused:   msg.Paused,
+		Created:  msg.Created.Format(time.RFC3339),
+	}
+}
+
 // mapConsumerInfo converts JetStream ConsumerInfo to GraphQL model.
 func mapConsumerInfo(ci *jetstream.ConsumerInfo) *model.ConsumerInfo {
 	result := &model.ConsumerInfo{
-		Stream:         ci.Stream,
-		Name:           ci.Name,
-		Created:        ci.Created.Format(time.RFC3339),
-		DeliverPolicy:  ci.Config.DeliverPolicy.String(),
-		AckPolicy:      ci.Config.AckPolicy.String(),
-		AckWait:        int(ci.Config.AckWait),
-		MaxDeliver:     ci.Config.MaxDeliver,
-		MaxAckPending:  ci.Config.MaxAckPending,
-		Replicas:       ci.Config.Replicas,
-		NumAckPending:  ci.NumAckPending,
-		NumRedelivered: ci.NumRedelivered,
-		NumWaiting:     ci.NumWaiting,
-		NumPending:     int(ci.NumPending),
-		Paused:         ci.Paused,
+		Stream:            ci.Stream,
+		Name:              ci.Name,
+		Created:           ci.Created.Format(time.RFC3339),
+		DeliverPolicy:     ci.Config.DeliverPolicy.String(),
+		AckPolicy:         ci.Config.AckPolicy.String(),
+		AckWait:           int(ci.Config.AckWait),
+		MaxDeliver:        ci.Config.MaxDeliver,
+		MaxAckPending:     ci.Config.MaxAckPending,
+		Replicas:          ci.Config.Replicas,
+		MaxRequestBatch:   ci.Config.MaxRequestBatch,
+		MaxRequestExpires: int(ci.Config.MaxRequestExpires),
+		RateLimit:         int(ci.Config.RateLimit),
+		HeadersOnly:       ci.Config.HeadersOnly,
+		MemoryStorage:     ci.Config.MemoryStorage,
+		Metadata:          mapMetadata(ci.Config.Metadata),
+		NumAckPending:     ci.NumAckPending,
+		NumRedelivered:    ci.NumRedelivered,
+		NumWaiting:        ci.NumWaiting,
+		NumPending:        int(ci.NumPending),
+		Paused:            ci.Paused,
 	}
 
 	if ci.Config.Description != "" {
@@ -106,6 +599,20 @@ func mapConsumerInfo(ci *jetstream.ConsumerInfo) *model.ConsumerInfo {
 	if len(ci.Config.FilterSubjects) > 0 {
 		result.FilterSubjects = ci.Config.FilterSubjects
 	}
+	if len(ci.Config.BackOff) > 0 {
+		result.Backoff = make([]int, len(ci.Config.BackOff))
+		for i, d := range ci.Config.BackOff {
+			result.Backoff[i] = int(d)
+		}
+	}
+	if ci.Config.InactiveThreshold > 0 {
+		it := int(ci.Config.InactiveThreshold)
+		result.InactiveThreshold = &it
+	}
+	if ci.Config.SampleFrequency != "" {
+		sf := ci.Config.SampleFrequency
+		result.SampleFrequency = &sf
+	}
 	if ci.PauseRemaining > 0 {
 		pr := int(ci.PauseRemaining)
 		result.PauseRemaining = &pr
@@ -114,6 +621,110 @@ func mapConsumerInfo(ci *jetstream.ConsumerInfo) *model.ConsumerInfo {
 	return result
 }
 
+// mapAccountInfo converts JetStream AccountInfo to GraphQL model.
+func mapAccountInfo(info *jetstream.AccountInfo) *model.AccountInfo {
+	result := &model.AccountInfo{
+		Memory:          int(info.Memory),
+		Storage:         int(info.Store),
+		ReservedMemory:  int(info.ReservedMemory),
+		ReservedStorage: int(info.ReservedStore),
+		Streams:         info.Streams,
+		Consumers:       info.Consumers,
+		Limits:          mapAccountLimits(info.Limits),
+		API: &model.AccountAPIStats{
+			Level:    info.API.Level,
+			Total:    int(info.API.Total),
+			Errors:   int(info.API.Errors),
+			Inflight: int(info.API.Inflight),
+		},
+		Tiers: make([]*model.AccountTier, 0, len(info.Tiers)),
+	}
+	if info.Domain != "" {
+		domain := info.Domain
+		result.Domain = &domain
+	}
+
+	// Sort tier names for deterministic output
+	names := make([]string, 0, len(info.Tiers))
+	for name := range info.Tiers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		tier := info.Tiers[name]
+		result.Tiers = append(result.Tiers, &model.AccountTier{
+			Name:            name,
+			Memory:          int(tier.Memory),
+			Storage:         int(tier.Store),
+			ReservedMemory:  int(tier.ReservedMemory),
+			ReservedStorage: int(tier.ReservedStore),
+			Streams:         tier.Streams,
+			Consumers:       tier.Consumers,
+			Limits:          mapAccountLimits(tier.Limits),
+		})
+	}
+	return result
+}
+
+// mapAccountLimits converts JetStream account limits to GraphQL model.
+func mapAccountLimits(limits jetstream.AccountLimits) *model.AccountLimits {
+	return &model.AccountLimits{
+		MaxMemory:    int(limits.MaxMemory),
+		MaxStorage:   int(limits.MaxStore),
+		MaxStreams:   limits.MaxStreams,
+		MaxConsumers: limits.MaxConsumers,
+	}
+}
+
+// mapStreamInfo converts JetStream StreamInfo to GraphQL model.
+func mapStreamInfo(info *jetstream.StreamInfo) *model.StreamInfo {
+	// Subjects is non-null in the schema, but sourcing streams may have none
+	subjects := make([]string, 0, len(info.Config.Subjects))
+	subjects = append(subjects, info.Config.Subjects...)
+
+	result := &model.StreamInfo{
+		Name:         info.Config.Name,
+		Subjects:     subjects,
+		Retention:    info.Config.Retention.String(),
+		MaxConsumers: info.Config.MaxConsumers,
+		MaxMsgs:      int(info.Config.MaxMsgs),
+		MaxBytes:     int(info.Config.MaxBytes),
+		MaxAge:       int(info.Config.MaxAge / time.Second),
+		Storage:      info.Config.Storage.String(),
+		Replicas:     info.Config.Replicas,
+		Messages:     int(info.State.Msgs),
+		Bytes:        int(info.State.Bytes),
+		Consumers:    info.State.Consumers,
+		Created:      info.Created.Format(time.RFC3339),
+		Sources:      mapSources(info.Sources),
+		Discard:      info.Config.Discard.String(),
+		Duplicates:   int(info.Config.Duplicates / time.Second),
+		AllowRollup:  info.Config.AllowRollup,
+		DenyDelete:   info.Config.DenyDelete,
+		DenyPurge:    info.Config.DenyPurge,
+		FirstSeq:     int(info.State.FirstSeq),
+		LastSeq:      int(info.State.LastSeq),
+		NumDeleted:   info.State.NumDeleted,
+		NumSubjects:  int(info.State.NumSubjects),
+	}
+
+	if info.Config.Description != "" {
+		desc := info.Config.Description
+		result.Description = &desc
+	}
+	if !info.State.FirstTime.IsZero() && info.State.Msgs > 0 {
+		ts := info.State.FirstTime.Format(time.RFC3339Nano)
+		result.FirstTs = &ts
+	}
+	if !info.State.LastTime.IsZero() {
+		ts := info.State.LastTime.Format(time.RFC3339Nano)
+		result.LastTs = &ts
+	}
+
+	return result
+}
+
 // mapSources converts JetStream StreamSourceInfo to GraphQL model.
 // Returns nil if no sources are present (so the field is null in the response).
 func mapSources(sources []*jetstream.StreamSourceInfo) []*model.StreamSourceInfo {
@@ -135,3 +746,76 @@ func mapSources(sources []*jetstream.StreamSourceInfo) []*model.StreamSourceInfo
 	}
 	return result
 }
+
+// mapObjectStore converts JetStream ObjectStoreStatus to GraphQL model.
+func mapObjectStore(status jetstream.ObjectStoreStatus) *model.ObjectStore {
+	result := &model.ObjectStore{
+		Bucket:       status.Bucket(),
+		TTL:          int(status.TTL().Seconds()),
+		Storage:      status.Storage().String(),
+		Replicas:     status.Replicas(),
+		Size:         int(status.Size()),
+		Sealed:       status.Sealed(),
+		IsCompressed: status.IsCompressed(),
+	}
+	if status.Description() != "" {
+		desc := status.Description()
+		result.Description = &desc
+	}
+	return result
+}
+
+// mapObjectInfo converts JetStream ObjectInfo to GraphQL model.
+func mapObjectInfo(info *jetstream.ObjectInfo) *model.ObjectInfo {
+	result := &model.ObjectInfo{
+		Bucket:   info.Bucket,
+		Name:     info.Name,
+		Size:     int(info.Size),
+		Chunks:   int(info.Chunks),
+		Modified: info.ModTime.Format(time.RFC3339),
+		Headers:  mapHeaders(info.Headers),
+		Metadata: mapMetadata(info.Metadata),
+	}
+
+	if info.Description != "" {
+		desc := info.Description
+		result.Description = &desc
+	}
+	if info.Digest != "" {
+		digest := info.Digest
+		result.Digest = &digest
+	}
+	if info.Opts != nil && info.Opts.Link != nil {
+		link := &model.ObjectLink{Bucket: info.Opts.Link.Bucket}
+		if info.Opts.Link.Name != "" {
+			name := info.Opts.Link.Name
+			link.Name = &name
+		}
+		result.Link = link
+	}
+
+	return result
+}
+
+// mapKVEntry converts a JetStream KeyValueEntry to GraphQL model.
+func mapKVEntry(entry jetstream.KeyValueEntry) *model.KVEntry {
+	return &model.KVEntry{
+		Key:       entry.Key(),
+		Value:     string(entry.Value()),
+		Revision:  int(entry.Revision()),
+		Created:   entry.Created().Format(time.RFC3339),
+		Operation: mapKVOperation(entry.Operation()),
+	}
+}
+
+// mapKVOperation converts a JetStream KeyValueOp to GraphQL enum.
+func mapKVOperation(op jetstream.KeyValueOp) model.KVOperation {
+	switch op {
+	case jetstream.KeyValueDelete:
+		return model.KVOperationDel
+	case jetstream.KeyValuePurge:
+		return model.KVOperationPurge
+	default:
+		return model.KVOperationPut
+	}
+}
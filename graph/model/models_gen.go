@@ -2,6 +2,201 @@
 
 package model
 
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Advisory or metric event published by JetStream.
+// Use inline fragments (e.g. "... on MaxDeliveriesEvent") to select the fields of each event type.
+type JetStreamEvent interface {
+	IsJetStreamEvent()
+	// Event type
+	GetType() JetStreamEventType
+	// Unique event ID
+	GetID() string
+	// When the event was emitted, in RFC3339 format
+	GetTimestamp() string
+	// Stream the event is about
+	GetStream() string
+}
+
+// JetStream API usage counters of an account.
+type AccountAPIStats struct {
+	// JetStream API level supported by the server
+	Level int `json:"level"`
+	// Total number of API calls
+	Total int `json:"total"`
+	// Number of API calls that returned an error
+	Errors int `json:"errors"`
+	// Number of API calls currently in flight
+	Inflight int `json:"inflight"`
+}
+
+// JetStream usage and limits of the account the gateway is connected with.
+type AccountInfo struct {
+	// JetStream domain of the account. Null if not set
+	Domain *string `json:"domain,omitempty"`
+	// Memory used by streams in bytes
+	Memory int `json:"memory"`
+	// Disk storage used by streams in bytes
+	Storage int `json:"storage"`
+	// Memory reserved by streams with a max bytes limit, in bytes
+	ReservedMemory int `json:"reservedMemory"`
+	// Disk storage reserved by streams with a max bytes limit, in bytes
+	ReservedStorage int `json:"reservedStorage"`
+	// Number of streams in the account
+	Streams int `json:"streams"`
+	// Number of consumers in the account
+	Consumers int `json:"consumers"`
+	// Account limits
+	Limits *AccountLimits `json:"limits"`
+	// JetStream API usage counters
+	API *AccountAPIStats `json:"api"`
+	// Usage and limits per replication tier (e.g. R1, R3), sorted by name. Empty unless tiered limits are configured
+	Tiers []*AccountTier `json:"tiers"`
+}
+
+// JetStream limits of an account or tier. -1 means unlimited.
+type AccountLimits struct {
+	// Maximum memory storage in bytes
+	MaxMemory int `json:"maxMemory"`
+	// Maximum disk storage in bytes
+	MaxStorage int `json:"maxStorage"`
+	// Maximum number of streams
+	MaxStreams int `json:"maxStreams"`
+	// Maximum number of consumers
+	MaxConsumers int `json:"maxConsumers"`
+}
+
+// JetStream usage and limits of a single account tier.
+type AccountTier struct {
+	// Tier name (e.g. R1, R3)
+	Name string `json:"name"`
+	// Memory used by streams in bytes
+	Memory int `json:"memory"`
+	// Disk storage used by streams in bytes
+	Storage int `json:"storage"`
+	// Memory reserved by streams with a max bytes limit, in bytes
+	ReservedMemory int `json:"reservedMemory"`
+	// Disk storage reserved by streams with a max bytes limit, in bytes
+	ReservedStorage int `json:"reservedStorage"`
+	// Number of streams in the tier
+	Streams int `json:"streams"`
+	// Number of consumers in the tier
+	Consumers int `json:"consumers"`
+	// Tier limits
+	Limits *AccountLimits `json:"limits"`
+}
+
+// Sampled time between delivering a message and receiving its acknowledgement.
+type AckLatencyEvent struct {
+	// Event type (ACK_LATENCY)
+	Type JetStreamEventType `json:"type"`
+	// Unique event ID
+	ID string `json:"id"`
+	// When the event was emitted, in RFC3339 format
+	Timestamp string `json:"timestamp"`
+	// Stream the event is about
+	Stream string `json:"stream"`
+	// Consumer the message was acknowledged on
+	Consumer string `json:"consumer"`
+	// Stream sequence of the message
+	StreamSeq int `json:"streamSeq"`
+	// Consumer sequence of the delivery
+	ConsumerSeq int `json:"consumerSeq"`
+	// Number of delivery attempts
+	Deliveries int `json:"deliveries"`
+	// Time from delivery to acknowledgement, in nanoseconds
+	AckTime int `json:"ackTime"`
+}
+
+func (AckLatencyEvent) IsJetStreamEvent() {}
+
+// Event type
+func (this AckLatencyEvent) GetType() JetStreamEventType { return this.Type }
+
+// Unique event ID
+func (this AckLatencyEvent) GetID() string { return this.ID }
+
+// When the event was emitted, in RFC3339 format
+func (this AckLatencyEvent) GetTimestamp() string { return this.Timestamp }
+
+// Stream the event is about
+func (this AckLatencyEvent) GetStream() string { return this.Stream }
+
+// Client connection to a NATS server.
+type Connection struct {
+	// Connection ID (unique per server)
+	Cid int `json:"cid"`
+	// Name the client set when connecting. Null if not set
+	Name *string `json:"name,omitempty"`
+	// Client IP address
+	IP string `json:"ip"`
+	// Client port
+	Port int `json:"port"`
+	// Account the client is bound to. Null if not reported
+	Account *string `json:"account,omitempty"`
+	// Authenticated user. Null if not reported
+	User *string `json:"user,omitempty"`
+	// Client library language. Null if not reported
+	Lang *string `json:"lang,omitempty"`
+	// Client library version. Null if not reported
+	Version *string `json:"version,omitempty"`
+	// When the connection was opened, in RFC3339 format
+	Start string `json:"start"`
+	// Last activity on the connection, in RFC3339 format
+	LastActivity string `json:"lastActivity"`
+	// Round-trip time as reported by the server (e.g. 1.2ms). Null if not measured yet
+	Rtt *string `json:"rtt,omitempty"`
+	// Connection uptime as reported by the server (e.g. 2h5m)
+	Uptime string `json:"uptime"`
+	// Time since the last activity as reported by the server (e.g. 12s)
+	Idle string `json:"idle"`
+	// Bytes waiting to be sent to the client
+	PendingBytes int `json:"pendingBytes"`
+	// Messages received from the client
+	InMsgs int `json:"inMsgs"`
+	// Messages sent to the client
+	OutMsgs int `json:"outMsgs"`
+	// Bytes received from the client
+	InBytes int `json:"inBytes"`
+	// Bytes sent to the client
+	OutBytes int `json:"outBytes"`
+	// Number of subscriptions
+	Subscriptions int `json:"subscriptions"`
+}
+
+// A consumer was created or deleted.
+type ConsumerActionEvent struct {
+	// Event type (CONSUMER_CREATED or CONSUMER_DELETED)
+	Type JetStreamEventType `json:"type"`
+	// Unique event ID
+	ID string `json:"id"`
+	// When the event was emitted, in RFC3339 format
+	Timestamp string `json:"timestamp"`
+	// Stream the event is about
+	Stream string `json:"stream"`
+	// Consumer name
+	Consumer string `json:"consumer"`
+}
+
+func (ConsumerActionEvent) IsJetStreamEvent() {}
+
+// Event type
+func (this ConsumerActionEvent) GetType() JetStreamEventType { return this.Type }
+
+// Unique event ID
+func (this ConsumerActionEvent) GetID() string { return this.ID }
+
+// When the event was emitted, in RFC3339 format
+func (this ConsumerActionEvent) GetTimestamp() string { return this.Timestamp }
+
+// Stream the event is about
+func (this ConsumerActionEvent) GetStream() string { return this.Stream }
+
 // NATS JetStream consumer information.
 // Represents metadata about a consumer including its configuration and current runtime state.
 type ConsumerInfo struct {
@@ -29,6 +224,24 @@ type ConsumerInfo struct {
 	MaxDeliver int `json:"maxDeliver"`
 	// Maximum number of outstanding unacknowledged messages. -1 means unlimited
 	MaxAckPending int `json:"maxAckPending"`
+	// Redelivery backoff schedule in nanoseconds, applied per delivery attempt. Null if not set
+	Backoff []int `json:"backoff,omitempty"`
+	// Percentage of acknowledgements sampled for observability (e.g. "100%"). Null if sampling is disabled
+	SampleFrequency *string `json:"sampleFrequency,omitempty"`
+	// Time in nanoseconds after which an inactive consumer is removed. Null if not set
+	InactiveThreshold *int `json:"inactiveThreshold,omitempty"`
+	// Maximum batch size a single pull request may ask for. 0 means unlimited
+	MaxRequestBatch int `json:"maxRequestBatch"`
+	// Maximum expiry of a single pull request, in nanoseconds. 0 means unlimited
+	MaxRequestExpires int `json:"maxRequestExpires"`
+	// Delivery rate limit in bits per second (push consumers only). 0 means unlimited
+	RateLimit int `json:"rateLimit"`
+	// Whether only message headers (and size) are delivered, without payload
+	HeadersOnly bool `json:"headersOnly"`
+	// Whether the consumer state is kept in memory instead of on disk
+	MemoryStorage bool `json:"memoryStorage"`
+	// User-defined metadata, sorted by key. Null if no metadata is set
+	Metadata []*MetadataEntry `json:"metadata,omitempty"`
 	// Number of replicas for the consumer state
 	Replicas int `json:"replicas"`
 	// Number of messages delivered but not yet acknowledged
@@ -45,6 +258,30 @@ type ConsumerInfo struct {
 	PauseRemaining *int `json:"pauseRemaining,omitempty"`
 }
 
+// Message fetched from a pull consumer, waiting to be acknowledged.
+type ConsumerMessage struct {
+	// Opaque ack token. Pass it to messageAck / messageNak / messageTerm / messageInProgress
+	Token string `json:"token"`
+	// The message itself
+	Message *StreamMessage `json:"message"`
+	// How many times this message has been delivered (1 on the first delivery)
+	DeliveryCount int `json:"deliveryCount"`
+	// Messages still pending for the consumer after this one
+	Pending int `json:"pending"`
+}
+
+// Message received over core NATS (not stored in a stream), e.g. a request reply.
+type CoreMessage struct {
+	// Subject the message was sent to
+	Subject string `json:"subject"`
+	// Reply subject set by the sender. Null if none
+	Reply *string `json:"reply,omitempty"`
+	// Message payload, encoded as requested (default UTF-8 string)
+	Data string `json:"data"`
+	// Message headers (key-value pairs). Null if no headers were set
+	Headers []*HeaderEntry `json:"headers,omitempty"`
+}
+
 // Single header entry from a NATS message.
 // A header key can have multiple values (like HTTP headers).
 type HeaderEntry struct {
@@ -54,16 +291,84 @@ type HeaderEntry struct {
 	Values []string `json:"values"`
 }
 
+// State of the JetStream meta cluster (the RAFT group that manages streams and consumers).
+type JetStreamMetaCluster struct {
+	// Cluster name
+	Name string `json:"name"`
+	// Name of the current meta leader. Null while no leader is elected
+	Leader *string `json:"leader,omitempty"`
+	// Number of servers in the meta group
+	ClusterSize int `json:"clusterSize"`
+	// Number of pending meta operations
+	Pending int `json:"pending"`
+	// Other members of the meta group, as seen by the reporting server
+	Replicas []*JetStreamPeer `json:"replicas"`
+}
+
+// Member of a JetStream RAFT group.
+type JetStreamPeer struct {
+	// Server name
+	Name string `json:"name"`
+	// Whether the peer is caught up with the leader
+	Current bool `json:"current"`
+	// Whether the peer is offline
+	Offline bool `json:"offline"`
+	// Time since the peer was last seen, in nanoseconds
+	Active int `json:"active"`
+	// Number of operations the peer is behind
+	Lag int `json:"lag"`
+}
+
+// JetStream usage of a NATS server ($SYS JSZ).
+type JetStreamServerReport struct {
+	// Server ID
+	ServerID string `json:"serverId"`
+	// Server name
+	ServerName string `json:"serverName"`
+	// Whether JetStream is enabled on the server. All other fields are zero if not
+	Enabled bool `json:"enabled"`
+	// Maximum memory storage configured for JetStream, in bytes
+	MaxMemory int `json:"maxMemory"`
+	// Maximum disk storage configured for JetStream, in bytes
+	MaxStorage int `json:"maxStorage"`
+	// Memory used by streams in bytes
+	Memory int `json:"memory"`
+	// Disk storage used by streams in bytes
+	Storage int `json:"storage"`
+	// Memory reserved by streams with a max bytes limit, in bytes
+	ReservedMemory int `json:"reservedMemory"`
+	// Disk storage reserved by streams with a max bytes limit, in bytes
+	ReservedStorage int `json:"reservedStorage"`
+	// Number of accounts using JetStream
+	Accounts int `json:"accounts"`
+	// Number of replicated assets (streams and consumers) on the server
+	HaAssets int `json:"haAssets"`
+	// Number of streams on the server
+	Streams int `json:"streams"`
+	// Number of consumers on the server
+	Consumers int `json:"consumers"`
+	// Number of messages stored on the server
+	Messages int `json:"messages"`
+	// Bytes stored on the server
+	Bytes int `json:"bytes"`
+	// JetStream API usage counters
+	API *AccountAPIStats `json:"api"`
+	// State of the JetStream meta cluster. Null if the server is not clustered
+	MetaCluster *JetStreamMetaCluster `json:"metaCluster,omitempty"`
+}
+
 // Single key-value entry from a KV bucket.
 type KVEntry struct {
 	// Key name
 	Key string `json:"key"`
-	// Value stored under this key (as UTF-8 string)
+	// Value stored under this key, encoded as requested (default UTF-8 string)
 	Value string `json:"value"`
 	// Revision number (monotonically increasing version)
 	Revision int `json:"revision"`
 	// Timestamp when this revision was created, in RFC3339 format
 	Created string `json:"created"`
+	// Operation that produced this revision (PUT, DEL or PURGE)
+	Operation KVOperation `json:"operation"`
 }
 
 // NATS JetStream Key-Value store information.
@@ -85,9 +390,183 @@ type KeyValue struct {
 	IsCompressed bool `json:"isCompressed"`
 }
 
+// A new leader was elected for a clustered stream or consumer.
+type LeaderElectedEvent struct {
+	// Event type (STREAM_LEADER_ELECTED or CONSUMER_LEADER_ELECTED)
+	Type JetStreamEventType `json:"type"`
+	// Unique event ID
+	ID string `json:"id"`
+	// When the event was emitted, in RFC3339 format
+	Timestamp string `json:"timestamp"`
+	// Stream the event is about
+	Stream string `json:"stream"`
+	// Consumer name. Null for stream leader elections
+	Consumer *string `json:"consumer,omitempty"`
+	// Name of the new leader server
+	Leader string `json:"leader"`
+	// Other members of the RAFT group
+	Replicas []*JetStreamPeer `json:"replicas"`
+}
+
+func (LeaderElectedEvent) IsJetStreamEvent() {}
+
+// Event type
+func (this LeaderElectedEvent) GetType() JetStreamEventType { return this.Type }
+
+// Unique event ID
+func (this LeaderElectedEvent) GetID() string { return this.ID }
+
+// When the event was emitted, in RFC3339 format
+func (this LeaderElectedEvent) GetTimestamp() string { return this.Timestamp }
+
+// Stream the event is about
+func (this LeaderElectedEvent) GetStream() string { return this.Stream }
+
+// A message was delivered maxDeliver times without being acknowledged (a poison message).
+type MaxDeliveriesEvent struct {
+	// Event type (MAX_DELIVERIES)
+	Type JetStreamEventType `json:"type"`
+	// Unique event ID
+	ID string `json:"id"`
+	// When the event was emitted, in RFC3339 format
+	Timestamp string `json:"timestamp"`
+	// Stream the event is about
+	Stream string `json:"stream"`
+	// Consumer that gave up on the message
+	Consumer string `json:"consumer"`
+	// Stream sequence of the message
+	StreamSeq int `json:"streamSeq"`
+	// Number of delivery attempts
+	Deliveries int `json:"deliveries"`
+}
+
+func (MaxDeliveriesEvent) IsJetStreamEvent() {}
+
+// Event type
+func (this MaxDeliveriesEvent) GetType() JetStreamEventType { return this.Type }
+
+// Unique event ID
+func (this MaxDeliveriesEvent) GetID() string { return this.ID }
+
+// When the event was emitted, in RFC3339 format
+func (this MaxDeliveriesEvent) GetTimestamp() string { return this.Timestamp }
+
+// Stream the event is about
+func (this MaxDeliveriesEvent) GetStream() string { return this.Stream }
+
+// A message was terminated by the client, so it will not be redelivered.
+type MessageTerminatedEvent struct {
+	// Event type (MESSAGE_TERMINATED)
+	Type JetStreamEventType `json:"type"`
+	// Unique event ID
+	ID string `json:"id"`
+	// When the event was emitted, in RFC3339 format
+	Timestamp string `json:"timestamp"`
+	// Stream the event is about
+	Stream string `json:"stream"`
+	// Consumer the message was terminated on
+	Consumer string `json:"consumer"`
+	// Stream sequence of the message
+	StreamSeq int `json:"streamSeq"`
+	// Consumer sequence of the delivery
+	ConsumerSeq int `json:"consumerSeq"`
+	// Number of delivery attempts
+	Deliveries int `json:"deliveries"`
+	// Reason given by the client. Null if none
+	Reason *string `json:"reason,omitempty"`
+}
+
+func (MessageTerminatedEvent) IsJetStreamEvent() {}
+
+// Event type
+func (this MessageTerminatedEvent) GetType() JetStreamEventType { return this.Type }
+
+// Unique event ID
+func (this MessageTerminatedEvent) GetID() string { return this.ID }
+
+// When the event was emitted, in RFC3339 format
+func (this MessageTerminatedEvent) GetTimestamp() string { return this.Timestamp }
+
+// Stream the event is about
+func (this MessageTerminatedEvent) GetStream() string { return this.Stream }
+
+// Single metadata entry (key-value pair) attached to a consumer or object.
+type MetadataEntry struct {
+	// Metadata key
+	Key string `json:"key"`
+	// Metadata value
+	Value string `json:"value"`
+}
+
 type Mutation struct {
 }
 
+// Information about an object stored in an Object Store.
+// Object data is transferred through the HTTP endpoints at /objects/{bucket}/{name}.
+type ObjectInfo struct {
+	// Bucket the object is stored in
+	Bucket string `json:"bucket"`
+	// Object name (may contain slashes)
+	Name string `json:"name"`
+	// Optional human-readable description
+	Description *string `json:"description,omitempty"`
+	// Object size in bytes
+	Size int `json:"size"`
+	// Number of chunks the object is split into
+	Chunks int `json:"chunks"`
+	// SHA-256 digest of the object data (e.g. SHA-256=...). Null for links
+	Digest *string `json:"digest,omitempty"`
+	// Last modification time in RFC3339 format
+	Modified string `json:"modified"`
+	// Object headers (key-value pairs). Null if no headers were set
+	Headers []*HeaderEntry `json:"headers,omitempty"`
+	// User-defined metadata, sorted by key. Null if no metadata is set
+	Metadata []*MetadataEntry `json:"metadata,omitempty"`
+	// Target of the link if this object is a link. Null for regular objects
+	Link *ObjectLink `json:"link,omitempty"`
+}
+
+// Target of an Object Store link.
+type ObjectLink struct {
+	// Bucket the link points to
+	Bucket string `json:"bucket"`
+	// Object the link points to. Null if the link points to the whole bucket
+	Name *string `json:"name,omitempty"`
+}
+
+// NATS JetStream Object Store information.
+// Object stores hold files of any size, split into chunks on top of a stream.
+type ObjectStore struct {
+	// Name of the object store bucket
+	Bucket string `json:"bucket"`
+	// Optional human-readable description
+	Description *string `json:"description,omitempty"`
+	// Maximum age of objects in seconds. 0 means objects do not expire
+	TTL int `json:"ttl"`
+	// Storage type: File (persistent to disk) or Memory (in-memory only)
+	Storage string `json:"storage"`
+	// Number of replicas
+	Replicas int `json:"replicas"`
+	// Total size of the bucket in bytes, including chunk overhead
+	Size int `json:"size"`
+	// Whether the bucket is sealed (read-only)
+	Sealed bool `json:"sealed"`
+	// Whether the bucket data is compressed
+	IsCompressed bool `json:"isCompressed"`
+}
+
+// Relay-style pagination info.
+type PageInfo struct {
+	// Whether more messages exist after endCursor
+	HasNextPage bool `json:"hasNextPage"`
+	// Whether more messages exist before startCursor
+	HasPreviousPage bool `json:"hasPreviousPage"`
+	// Cursor of the first edge. Null if the page is empty
+	StartCursor *string `json:"startCursor,omitempty"`
+	// Cursor of the last edge. Null if the page is empty
+	EndCursor *string `json:"endCursor,omitempty"`
+}
+
 // Result of publishing a message to NATS.
 type PublishResult struct {
 	// Stream that accepted the message
@@ -99,6 +578,225 @@ type PublishResult struct {
 type Query struct {
 }
 
+// Message published repeatedly on a cron schedule by publishRecurring.
+type RecurringMessage struct {
+	// Recurring schedule ID
+	ID string `json:"id"`
+	// Subject the message is published to
+	Subject string `json:"subject"`
+	// Message payload, encoded as requested (default UTF-8 string)
+	Data string `json:"data"`
+	// Message headers (key-value pairs). Null if no headers were set
+	Headers []*HeaderEntry `json:"headers,omitempty"`
+	// Cron expression (5 fields or a descriptor like @hourly)
+	Cron string `json:"cron"`
+	// IANA timezone the cron expression is evaluated in
+	Timezone string `json:"timezone"`
+	// Next publish time in RFC3339 format (while paused: the last planned one)
+	NextRun string `json:"nextRun"`
+	// Whether publishing is paused
+	Paused bool `json:"paused"`
+	// When the recurring message was created, in RFC3339 format
+	Created string `json:"created"`
+}
+
+// Message waiting to be published by publishScheduled.
+type ScheduledMessage struct {
+	// Schedule ID returned by publishScheduled
+	ID string `json:"id"`
+	// Subject the message will be published to
+	Subject string `json:"subject"`
+	// Message payload, encoded as requested (default UTF-8 string)
+	Data string `json:"data"`
+	// Message headers (key-value pairs). Null if no headers were set
+	Headers []*HeaderEntry `json:"headers,omitempty"`
+	// When the message will be published, in RFC3339 format
+	FireAt string `json:"fireAt"`
+	// When the message was scheduled, in RFC3339 format
+	Created string `json:"created"`
+}
+
+// Client connections of a NATS server ($SYS CONNZ).
+type ServerConnections struct {
+	// Server ID
+	ServerID string `json:"serverId"`
+	// Server name
+	ServerName string `json:"serverName"`
+	// Number of open connections on the server matching the filter (may exceed the returned list)
+	Total int `json:"total"`
+	// Connections, at most limit per server
+	Connections []*Connection `json:"connections"`
+}
+
+// Health of a NATS server ($SYS HEALTHZ).
+type ServerHealth struct {
+	// Server ID
+	ServerID string `json:"serverId"`
+	// Server name
+	ServerName string `json:"serverName"`
+	// Whether the server reports itself healthy
+	Healthy bool `json:"healthy"`
+	// Status reported by the server (e.g. ok, unavailable)
+	Status string `json:"status"`
+	// Reason the server is unhealthy. Null if healthy
+	Error *string `json:"error,omitempty"`
+}
+
+// General information and statistics of a NATS server ($SYS VARZ).
+type ServerInfo struct {
+	// Server ID
+	ServerID string `json:"serverId"`
+	// Server name
+	ServerName string `json:"serverName"`
+	// NATS server version
+	Version string `json:"version"`
+	// Host the server listens on for clients
+	Host string `json:"host"`
+	// Port the server listens on for clients
+	Port int `json:"port"`
+	// Cluster name. Null if the server is not clustered
+	Cluster *string `json:"cluster,omitempty"`
+	// JetStream domain. Null if not set
+	Domain *string `json:"domain,omitempty"`
+	// Whether JetStream is enabled on the server
+	Jetstream bool `json:"jetstream"`
+	// When the server was started, in RFC3339 format
+	Start string `json:"start"`
+	// Uptime as reported by the server (e.g. 3d4h12m5s)
+	Uptime string `json:"uptime"`
+	// Resident memory of the server process in bytes
+	Memory int `json:"memory"`
+	// Number of CPU cores
+	Cores int `json:"cores"`
+	// CPU usage in percent
+	CPU float64 `json:"cpu"`
+	// Current number of client connections
+	Connections int `json:"connections"`
+	// Number of client connections since the server started
+	TotalConnections int `json:"totalConnections"`
+	// Current number of cluster routes
+	Routes int `json:"routes"`
+	// Current number of leaf node connections
+	Leafnodes int `json:"leafnodes"`
+	// Current number of subscriptions
+	Subscriptions int `json:"subscriptions"`
+	// Number of slow consumers detected since the server started
+	SlowConsumers int `json:"slowConsumers"`
+	// Messages received since the server started
+	InMsgs int `json:"inMsgs"`
+	// Messages sent since the server started
+	OutMsgs int `json:"outMsgs"`
+	// Bytes received since the server started
+	InBytes int `json:"inBytes"`
+	// Bytes sent since the server started
+	OutBytes int `json:"outBytes"`
+	// Maximum message payload in bytes
+	MaxPayload int `json:"maxPayload"`
+}
+
+// Instance of a service built with the NATS micro framework, as reported by $SRV.INFO.
+// Every running instance answers separately with its own id.
+type Service struct {
+	// Service name
+	Name string `json:"name"`
+	// Unique ID of this instance
+	ID string `json:"id"`
+	// Service version (SemVer)
+	Version string `json:"version"`
+	// Service description. Null if not set
+	Description *string `json:"description,omitempty"`
+	// Service metadata, sorted by key. Null if no metadata is set
+	Metadata []*MetadataEntry `json:"metadata,omitempty"`
+	// Endpoints the service exposes
+	Endpoints []*ServiceEndpoint `json:"endpoints"`
+}
+
+// Endpoint of a NATS micro service.
+type ServiceEndpoint struct {
+	// Endpoint name (pass it to serviceInvoke)
+	Name string `json:"name"`
+	// Subject the endpoint listens on
+	Subject string `json:"subject"`
+	// Queue group shared by the service instances. Null if queue groups are disabled
+	QueueGroup *string `json:"queueGroup,omitempty"`
+	// Endpoint metadata, sorted by key. Services often describe request/response schemas here. Null if no metadata is set
+	Metadata []*MetadataEntry `json:"metadata,omitempty"`
+}
+
+// Request statistics of a single NATS micro service endpoint.
+type ServiceEndpointStats struct {
+	// Endpoint name
+	Name string `json:"name"`
+	// Subject the endpoint listens on
+	Subject string `json:"subject"`
+	// Queue group shared by the service instances. Null if queue groups are disabled
+	QueueGroup *string `json:"queueGroup,omitempty"`
+	// Number of requests handled
+	NumRequests int `json:"numRequests"`
+	// Number of requests answered with an error
+	NumErrors int `json:"numErrors"`
+	// Last error returned by the endpoint. Null if none
+	LastError *string `json:"lastError,omitempty"`
+	// Total time spent handling requests, in nanoseconds
+	ProcessingTime int `json:"processingTime"`
+	// Average time spent per request, in nanoseconds
+	AverageProcessingTime int `json:"averageProcessingTime"`
+	// Custom statistics from the service's stats handler, as a JSON string. Null if not provided
+	Data *string `json:"data,omitempty"`
+}
+
+// Reply of a NATS micro service instance to $SRV.PING.
+type ServicePing struct {
+	// Service name
+	Name string `json:"name"`
+	// Unique ID of this instance
+	ID string `json:"id"`
+	// Service version (SemVer)
+	Version string `json:"version"`
+	// Service metadata, sorted by key. Null if no metadata is set
+	Metadata []*MetadataEntry `json:"metadata,omitempty"`
+}
+
+// Request statistics of a NATS micro service instance, as reported by $SRV.STATS.
+type ServiceStats struct {
+	// Service name
+	Name string `json:"name"`
+	// Unique ID of this instance
+	ID string `json:"id"`
+	// Service version (SemVer)
+	Version string `json:"version"`
+	// When the instance was started, in RFC3339 format
+	Started string `json:"started"`
+	// Statistics per endpoint
+	Endpoints []*ServiceEndpointStats `json:"endpoints"`
+}
+
+// A stream was created, deleted or updated.
+type StreamActionEvent struct {
+	// Event type (STREAM_CREATED, STREAM_DELETED or STREAM_UPDATED)
+	Type JetStreamEventType `json:"type"`
+	// Unique event ID
+	ID string `json:"id"`
+	// When the event was emitted, in RFC3339 format
+	Timestamp string `json:"timestamp"`
+	// Stream the event is about
+	Stream string `json:"stream"`
+}
+
+func (StreamActionEvent) IsJetStreamEvent() {}
+
+// Event type
+func (this StreamActionEvent) GetType() JetStreamEventType { return this.Type }
+
+// Unique event ID
+func (this StreamActionEvent) GetID() string { return this.ID }
+
+// When the event was emitted, in RFC3339 format
+func (this StreamActionEvent) GetTimestamp() string { return this.Timestamp }
+
+// Stream the event is about
+func (this StreamActionEvent) GetStream() string { return this.Stream }
+
 // NATS JetStream stream information.
 // Represents metadata about a stream including its configuration and current runtime state.
 type StreamInfo struct {
@@ -130,6 +828,34 @@ type StreamInfo struct {
 	Created string `json:"created"`
 	// List of source streams this stream is aggregating from. Null if not sourcing
 	Sources []*StreamSourceInfo `json:"sources,omitempty"`
+	// Optional human-readable description
+	Description *string `json:"description,omitempty"`
+	// Discard policy when limits are reached: DiscardOld (remove oldest messages) or DiscardNew (reject new messages)
+	Discard string `json:"discard"`
+	// Window for duplicate message detection (Nats-Msg-Id header) in seconds
+	Duplicates int `json:"duplicates"`
+	// Whether Nats-Rollup headers are allowed to purge older messages on publish
+	AllowRollup bool `json:"allowRollup"`
+	// Whether deleting individual messages is forbidden
+	DenyDelete bool `json:"denyDelete"`
+	// Whether purging the stream is forbidden
+	DenyPurge bool `json:"denyPurge"`
+	// Sequence number of the first message in the stream
+	FirstSeq int `json:"firstSeq"`
+	// Sequence number of the last message in the stream
+	LastSeq int `json:"lastSeq"`
+	// Timestamp of the first message in RFC3339 format. Null if the stream is empty
+	FirstTs *string `json:"firstTs,omitempty"`
+	// Timestamp of the last message in RFC3339 format. Null if no message was ever stored
+	LastTs *string `json:"lastTs,omitempty"`
+	// Number of deleted messages inside the first..last sequence range (gaps)
+	NumDeleted int `json:"numDeleted"`
+	// Number of distinct subjects currently holding messages
+	NumSubjects int `json:"numSubjects"`
+	// Message count per concrete subject, sorted by subject.
+	// - filter: subject filter, wildcards allowed (default ">" = all subjects)
+	// Note: each request loads all matching subjects, use a narrow filter on streams with many subjects
+	SubjectCounts []*SubjectCount `json:"subjectCounts"`
 }
 
 // Single message from a NATS JetStream stream.
@@ -138,12 +864,32 @@ type StreamMessage struct {
 	Sequence int `json:"sequence"`
 	// Subject the message was published to
 	Subject string `json:"subject"`
-	// Message payload as UTF-8 string
+	// Message payload, encoded as requested (default UTF-8 string)
 	Data string `json:"data"`
 	// Timestamp when the message was stored, in RFC3339 format
 	Published string `json:"published"`
 	// Message headers (key-value pairs). Null if no headers were set
 	Headers []*HeaderEntry `json:"headers,omitempty"`
+	// Only set by streamSubscribe: true on the first message after the subscription's ordered consumer
+	// was recreated (e.g. after a NATS reconnect or missed heartbeats). Delivery resumes after the last
+	// delivered sequence, so no messages are skipped. Always false outside subscriptions
+	ConsumerReset bool `json:"consumerReset"`
+}
+
+// Relay-style page of stream messages.
+type StreamMessageConnection struct {
+	// Messages on this page in stream order (oldest first)
+	Edges []*StreamMessageEdge `json:"edges"`
+	// Cursors and flags for fetching the neighbouring pages
+	PageInfo *PageInfo `json:"pageInfo"`
+}
+
+// Single message in a StreamMessageConnection.
+type StreamMessageEdge struct {
+	// Opaque cursor of this message, pass it as after/before to continue paging
+	Cursor string `json:"cursor"`
+	// The message itself
+	Node *StreamMessage `json:"node"`
 }
 
 // Information about an upstream source stream.
@@ -166,5 +912,302 @@ type StreamSourceInput struct {
 	FilterSubject *string `json:"filterSubject,omitempty"`
 }
 
+// Number of messages stored on a single subject of a stream.
+type SubjectCount struct {
+	// Concrete subject name
+	Subject string `json:"subject"`
+	// Number of messages currently stored on this subject
+	Messages int `json:"messages"`
+}
+
 type Subscription struct {
 }
+
+// Sort order for connections. Counters and durations sort largest first.
+type ConnectionSort string
+
+const (
+	// Connection ID
+	ConnectionSortCid ConnectionSort = "CID"
+	// Connection start time
+	ConnectionSortStart ConnectionSort = "START"
+	// Number of subscriptions
+	ConnectionSortSubscriptions ConnectionSort = "SUBSCRIPTIONS"
+	// Bytes waiting to be sent
+	ConnectionSortPending ConnectionSort = "PENDING"
+	// Messages sent to the client
+	ConnectionSortMsgsTo ConnectionSort = "MSGS_TO"
+	// Messages received from the client
+	ConnectionSortMsgsFrom ConnectionSort = "MSGS_FROM"
+	// Bytes sent to the client
+	ConnectionSortBytesTo ConnectionSort = "BYTES_TO"
+	// Bytes received from the client
+	ConnectionSortBytesFrom ConnectionSort = "BYTES_FROM"
+	// Last activity
+	ConnectionSortLastActivity ConnectionSort = "LAST_ACTIVITY"
+	// Idle time
+	ConnectionSortIdle ConnectionSort = "IDLE"
+	// Connection uptime
+	ConnectionSortUptime ConnectionSort = "UPTIME"
+)
+
+var AllConnectionSort = []ConnectionSort{
+	ConnectionSortCid,
+	ConnectionSortStart,
+	ConnectionSortSubscriptions,
+	ConnectionSortPending,
+	ConnectionSortMsgsTo,
+	ConnectionSortMsgsFrom,
+	ConnectionSortBytesTo,
+	ConnectionSortBytesFrom,
+	ConnectionSortLastActivity,
+	ConnectionSortIdle,
+	ConnectionSortUptime,
+}
+
+func (e ConnectionSort) IsValid() bool {
+	switch e {
+	case ConnectionSortCid, ConnectionSortStart, ConnectionSortSubscriptions, ConnectionSortPending, ConnectionSortMsgsTo, ConnectionSortMsgsFrom, ConnectionSortBytesTo, ConnectionSortBytesFrom, ConnectionSortLastActivity, ConnectionSortIdle, ConnectionSortUptime:
+		return true
+	}
+	return false
+}
+
+func (e ConnectionSort) String() string {
+	return string(e)
+}
+
+func (e *ConnectionSort) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ConnectionSort(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ConnectionSort", str)
+	}
+	return nil
+}
+
+func (e ConnectionSort) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ConnectionSort) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ConnectionSort) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Text encoding used to transfer binary payloads (message data, KV values) as GraphQL strings.
+type Encoding string
+
+const (
+	// Raw bytes as a UTF-8 string (invalid sequences are not preserved)
+	EncodingUTF8 Encoding = "UTF8"
+	// Standard base64 with padding (RFC 4648)
+	EncodingBase64 Encoding = "BASE64"
+	// Lowercase hexadecimal
+	EncodingHex Encoding = "HEX"
+)
+
+var AllEncoding = []Encoding{
+	EncodingUTF8,
+	EncodingBase64,
+	EncodingHex,
+}
+
+func (e Encoding) IsValid() bool {
+	switch e {
+	case EncodingUTF8, EncodingBase64, EncodingHex:
+		return true
+	}
+	return false
+}
+
+func (e Encoding) String() string {
+	return string(e)
+}
+
+func (e *Encoding) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Encoding(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Encoding", str)
+	}
+	return nil
+}
+
+func (e Encoding) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *Encoding) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e Encoding) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Kind of JetStream advisory or metric event.
+type JetStreamEventType string
+
+const (
+	// A message reached the consumer's maxDeliver limit without being acknowledged
+	JetStreamEventTypeMaxDeliveries JetStreamEventType = "MAX_DELIVERIES"
+	// A message was terminated by the client (messageTerm)
+	JetStreamEventTypeMessageTerminated JetStreamEventType = "MESSAGE_TERMINATED"
+	// A stream was created
+	JetStreamEventTypeStreamCreated JetStreamEventType = "STREAM_CREATED"
+	// A stream was deleted
+	JetStreamEventTypeStreamDeleted JetStreamEventType = "STREAM_DELETED"
+	// A stream configuration was updated
+	JetStreamEventTypeStreamUpdated JetStreamEventType = "STREAM_UPDATED"
+	// A consumer was created
+	JetStreamEventTypeConsumerCreated JetStreamEventType = "CONSUMER_CREATED"
+	// A consumer was deleted
+	JetStreamEventTypeConsumerDeleted JetStreamEventType = "CONSUMER_DELETED"
+	// A new leader was elected for a clustered stream
+	JetStreamEventTypeStreamLeaderElected JetStreamEventType = "STREAM_LEADER_ELECTED"
+	// A new leader was elected for a clustered consumer
+	JetStreamEventTypeConsumerLeaderElected JetStreamEventType = "CONSUMER_LEADER_ELECTED"
+	// Acknowledgement latency sample of a consumer (requires sampleFrequency on the consumer)
+	JetStreamEventTypeAckLatency JetStreamEventType = "ACK_LATENCY"
+)
+
+var AllJetStreamEventType = []JetStreamEventType{
+	JetStreamEventTypeMaxDeliveries,
+	JetStreamEventTypeMessageTerminated,
+	JetStreamEventTypeStreamCreated,
+	JetStreamEventTypeStreamDeleted,
+	JetStreamEventTypeStreamUpdated,
+	JetStreamEventTypeConsumerCreated,
+	JetStreamEventTypeConsumerDeleted,
+	JetStreamEventTypeStreamLeaderElected,
+	JetStreamEventTypeConsumerLeaderElected,
+	JetStreamEventTypeAckLatency,
+}
+
+func (e JetStreamEventType) IsValid() bool {
+	switch e {
+	case JetStreamEventTypeMaxDeliveries, JetStreamEventTypeMessageTerminated, JetStreamEventTypeStreamCreated, JetStreamEventTypeStreamDeleted, JetStreamEventTypeStreamUpdated, JetStreamEventTypeConsumerCreated, JetStreamEventTypeConsumerDeleted, JetStreamEventTypeStreamLeaderElected, JetStreamEventTypeConsumerLeaderElected, JetStreamEventTypeAckLatency:
+		return true
+	}
+	return false
+}
+
+func (e JetStreamEventType) String() string {
+	return string(e)
+}
+
+func (e *JetStreamEventType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = JetStreamEventType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid JetStreamEventType", str)
+	}
+	return nil
+}
+
+func (e JetStreamEventType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *JetStreamEventType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e JetStreamEventType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Operation recorded for a KV revision.
+type KVOperation string
+
+const (
+	// Value was created or updated
+	KVOperationPut KVOperation = "PUT"
+	// Key was deleted (tombstone marker)
+	KVOperationDel KVOperation = "DEL"
+	// Key was purged (all previous revisions removed)
+	KVOperationPurge KVOperation = "PURGE"
+)
+
+var AllKVOperation = []KVOperation{
+	KVOperationPut,
+	KVOperationDel,
+	KVOperationPurge,
+}
+
+func (e KVOperation) IsValid() bool {
+	switch e {
+	case KVOperationPut, KVOperationDel, KVOperationPurge:
+		return true
+	}
+	return false
+}
+
+func (e KVOperation) String() string {
+	return string(e)
+}
+
+func (e *KVOperation) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = KVOperation(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid KVOperation", str)
+	}
+	return nil
+}
+
+func (e KVOperation) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *KVOperation) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e KVOperation) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,349 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"nats-graphql/graph/model"
+	"sort"
+	"time"
+)
+
+// Server monitoring over the system account. Every server answers
+// $SYS.REQ.SERVER.PING.<endpoint> with the same data its HTTP monitoring
+// port serves on /<endpoint>, wrapped in sysResponse. Only the fields
+// exposed in the schema are decoded.
+
+// sysServer identifies the server that sent a monitoring reply.
+type sysServer struct {
+	Name      string `json:"name"`
+	ID        string `json:"id"`
+	Cluster   string `json:"cluster"`
+	Domain    string `json:"domain"`
+	JetStream bool   `json:"jetstream"`
+}
+
+// sysResponse is the envelope of every monitoring reply.
+type sysResponse struct {
+	Server sysServer       `json:"server"`
+	Data   json.RawMessage `json:"data"`
+	Error  *struct {
+		Code        int    `json:"code"`
+		Description string `json:"description"`
+	} `json:"error"`
+}
+
+type varz struct {
+	Version          string    `json:"version"`
+	Host             string    `json:"host"`
+	Port             int       `json:"port"`
+	MaxPayload       int       `json:"max_payload"`
+	Start            time.Time `json:"start"`
+	Uptime           string    `json:"uptime"`
+	Mem              int64     `json:"mem"`
+	Cores            int       `json:"cores"`
+	CPU              float64   `json:"cpu"`
+	Connections      int       `json:"connections"`
+	TotalConnections uint64    `json:"total_connections"`
+	Routes           int       `json:"routes"`
+	Leafnodes        int       `json:"leafnodes"`
+	Subscriptions    uint32    `json:"subscriptions"`
+	SlowConsumers    int64     `json:"slow_consumers"`
+	InMsgs           int64     `json:"in_msgs"`
+	OutMsgs          int64     `json:"out_msgs"`
+	InBytes          int64     `json:"in_bytes"`
+	OutBytes         int64     `json:"out_bytes"`
+}
+
+type connz struct {
+	Total       int        `json:"total"`
+	Connections []connInfo `json:"connections"`
+}
+
+type connInfo struct {
+	Cid            uint64    `json:"cid"`
+	Name           string    `json:"name"`
+	IP             string    `json:"ip"`
+	Port           int       `json:"port"`
+	Account        string    `json:"account"`
+	AuthorizedUser string    `json:"authorized_user"`
+	Lang           string    `json:"lang"`
+	Version        string    `json:"version"`
+	Start          time.Time `json:"start"`
+	LastActivity   time.Time `json:"last_activity"`
+	RTT            string    `json:"rtt"`
+	Uptime         string    `json:"uptime"`
+	Idle           string    `json:"idle"`
+	PendingBytes   int       `json:"pending_bytes"`
+	InMsgs         int64     `json:"in_msgs"`
+	OutMsgs        int64     `json:"out_msgs"`
+	InBytes        int64     `json:"in_bytes"`
+	OutBytes       int64     `json:"out_bytes"`
+	Subscriptions  uint32    `json:"subscriptions"`
+}
+
+// connzOptions is the request body of a CONNZ request.
+type connzOptions struct {
+	Sort    string `json:"sort,omitempty"`
+	Auth    bool   `json:"auth"`
+	Limit   int    `json:"limit,omitempty"`
+	Account string `json:"acc,omitempty"`
+}
+
+type jsz struct {
+	Disabled bool `json:"disabled"`
+	Config   struct {
+		MaxMemory int64 `json:"max_memory"`
+		MaxStore  int64 `json:"max_storage"`
+	} `json:"config"`
+	Memory         uint64 `json:"memory"`
+	Store          uint64 `json:"storage"`
+	ReservedMemory uint64 `json:"reserved_memory"`
+	ReservedStore  uint64 `json:"reserved_storage"`
+	Accounts       int    `json:"accounts"`
+	HAAssets       int    `json:"ha_assets"`
+	Streams        int    `json:"streams"`
+	Consumers      int    `json:"consumers"`
+	Messages       uint64 `json:"messages"`
+	Bytes          uint64 `json:"bytes"`
+	API            struct {
+		Level    int    `json:"level"`
+		Total    uint64 `json:"total"`
+		Errors   uint64 `json:"errors"`
+		Inflight uint64 `json:"inflight"`
+	} `json:"api"`
+	Meta *struct {
+		Name        string     `json:"name"`
+		Leader      string     `json:"leader"`
+		ClusterSize int        `json:"cluster_size"`
+		Pending     int        `json:"pending"`
+		Replicas    []peerInfo `json:"replicas"`
+	} `json:"meta_cluster"`
+}
+
+// peerInfo is a member of a RAFT group, as reported in JSZ and leader election advisories.
+type peerInfo struct {
+	Name    string        `json:"name"`
+	Current bool          `json:"current"`
+	Offline bool          `json:"offline"`
+	Active  time.Duration `json:"active"`
+	Lag     uint64        `json:"lag"`
+}
+
+type healthz struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+// connectionSorts maps ConnectionSort values to the CONNZ sort options.
+var connectionSorts = map[model.ConnectionSort]string{
+	model.ConnectionSortCid:           "cid",
+	model.ConnectionSortStart:         "start",
+	model.ConnectionSortSubscriptions: "subs",
+	model.ConnectionSortPending:       "pending",
+	model.ConnectionSortMsgsTo:        "msgs_to",
+	model.ConnectionSortMsgsFrom:      "msgs_from",
+	model.ConnectionSortBytesTo:       "bytes_to",
+	model.ConnectionSortBytesFrom:     "bytes_from",
+	model.ConnectionSortLastActivity:  "last",
+	model.ConnectionSortIdle:          "idle",
+	model.ConnectionSortUptime:        "uptime",
+}
+
+// sysRequest sends a monitoring request (e.g. "VARZ") to every server, or only to
+// serverID if set, and returns the replies sorted by server name.
+func (r *Resolver) sysRequest(ctx context.Context, endpoint string, serverID *string, opts any, timeout *int) ([]sysResponse, error) {
+	wait, err := discoveryWait(timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := "$SYS.REQ.SERVER.PING." + endpoint
+	limit := 0
+	if serverID != nil && *serverID != "" {
+		subject = fmt.Sprintf("$SYS.REQ.SERVER.%s.%s", *serverID, endpoint)
+		limit = 1
+	}
+
+	var body []byte
+	if opts != nil {
+		if body, err = json.Marshal(opts); err != nil {
+			return nil, err
+		}
+	}
+
+	replies, err := r.gatherReplies(ctx, subject, body, wait, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(replies) == 0 {
+		if limit > 0 {
+			return nil, fmt.Errorf("server %q did not answer", *serverID)
+		}
+		return nil, errors.New("no server answered, monitoring requires system account credentials")
+	}
+
+	result := make([]sysResponse, 0, len(replies))
+	for _, msg := range replies {
+		var resp sysResponse
+		if err := json.Unmarshal(msg.Data, &resp); err != nil {
+			continue
+		}
+		result = append(result, resp)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Server.Name < result[j].Server.Name
+	})
+
+	return result, nil
+}
+
+// optionalString returns nil for an empty string, so the field is null in the response.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// mapServerInfo converts a VARZ reply to GraphQL model.
+func mapServerInfo(server sysServer, v *varz) *model.ServerInfo {
+	return &model.ServerInfo{
+		ServerID:         server.ID,
+		ServerName:       server.Name,
+		Version:          v.Version,
+		Host:             v.Host,
+		Port:             v.Port,
+		Cluster:          optionalString(server.Cluster),
+		Domain:           optionalString(server.Domain),
+		Jetstream:        server.JetStream,
+		Start:            v.Start.Format(time.RFC3339),
+		Uptime:           v.Uptime,
+		Memory:           int(v.Mem),
+		Cores:            v.Cores,
+		CPU:              v.CPU,
+		Connections:      v.Connections,
+		TotalConnections: int(v.TotalConnections),
+		Routes:           v.Routes,
+		Leafnodes:        v.Leafnodes,
+		Subscriptions:    int(v.Subscriptions),
+		SlowConsumers:    int(v.SlowConsumers),
+		InMsgs:           int(v.InMsgs),
+		OutMsgs:          int(v.OutMsgs),
+		InBytes:          int(v.InBytes),
+		OutBytes:         int(v.OutBytes),
+		MaxPayload:       v.MaxPayload,
+	}
+}
+
+// mapServerConnections converts a CONNZ reply to GraphQL model.
+func mapServerConnections(server sysServer, c *connz) *model.ServerConnections {
+	result := &model.ServerConnections{
+		ServerID:    server.ID,
+		ServerName:  server.Name,
+		Total:       c.Total,
+		Connections: make([]*model.Connection, 0, len(c.Connections)),
+	}
+	for _, ci := range c.Connections {
+		result.Connections = append(result.Connections, &model.Connection{
+			Cid:           int(ci.Cid),
+			Name:          optionalString(ci.Name),
+			IP:            ci.IP,
+			Port:          ci.Port,
+			Account:       optionalString(ci.Account),
+			User:          optionalString(ci.AuthorizedUser),
+			Lang:          optionalString(ci.Lang),
+			Version:       optionalString(ci.Version),
+			Start:         ci.Start.Format(time.RFC3339),
+			LastActivity:  ci.LastActivity.Format(time.RFC3339),
+			Rtt:           optionalString(ci.RTT),
+			Uptime:        ci.Uptime,
+			Idle:          ci.Idle,
+			PendingBytes:  ci.PendingBytes,
+			InMsgs:        int(ci.InMsgs),
+			OutMsgs:       int(ci.OutMsgs),
+			InBytes:       int(ci.InBytes),
+			OutBytes:      int(ci.OutBytes),
+			Subscriptions: int(ci.Subscriptions),
+		})
+	}
+	return result
+}
+
+// mapJetStreamReport converts a JSZ reply to GraphQL model.
+func mapJetStreamReport(server sysServer, j *jsz) *model.JetStreamServerReport {
+	result := &model.JetStreamServerReport{
+		ServerID:        server.ID,
+		ServerName:      server.Name,
+		Enabled:         !j.Disabled,
+		MaxMemory:       int(j.Config.MaxMemory),
+		MaxStorage:      int(j.Config.MaxStore),
+		Memory:          int(j.Memory),
+		Storage:         int(j.Store),
+		ReservedMemory:  int(j.ReservedMemory),
+		ReservedStorage: int(j.ReservedStore),
+		Accounts:        j.Accounts,
+		HaAssets:        j.HAAssets,
+		Streams:         j.Streams,
+		Consumers:       j.Consumers,
+		Messages:        int(j.Messages),
+		Bytes:           int(j.Bytes),
+		API: &model.AccountAPIStats{
+			Level:    j.API.Level,
+			Total:    int(j.API.Total),
+			Errors:   int(j.API.Errors),
+			Inflight: int(j.API.Inflight),
+		},
+	}
+	if j.Meta != nil {
+		result.MetaCluster = &model.JetStreamMetaCluster{
+			Name:        j.Meta.Name,
+			Leader:      optionalString(j.Meta.Leader),
+			ClusterSize: j.Meta.ClusterSize,
+			Pending:     j.Meta.Pending,
+			Replicas:    mapPeers(j.Meta.Replicas),
+		}
+	}
+	return result
+}
+
+// mapPeers converts RAFT group members to GraphQL model.
+func mapPeers(peers []peerInfo) []*model.JetStreamPeer {
+	result := make([]*model.JetStreamPeer, 0, len(peers))
+	for _, p := range peers {
+		result = append(result, &model.JetStreamPeer{
+			Name:    p.Name,
+			Current: p.Current,
+			Offline: p.Offline,
+			Active:  int(p.Active),
+			Lag:     int(p.Lag),
+		})
+	}
+	return result
+}
+
+// mapServerHealth converts a HEALTHZ reply to GraphQL model. Unhealthy servers
+// report the reason both in the data and in the envelope error.
+func mapServerHealth(resp sysResponse) *model.ServerHealth {
+	var h healthz
+	json.Unmarshal(resp.Data, &h)
+	if h.Error == "" && resp.Error != nil {
+		h.Error = resp.Error.Description
+	}
+	if h.Status == "" {
+		h.Status = "unavailable"
+		if h.Error == "" {
+			h.Status = "ok"
+		}
+	}
+
+	return &model.ServerHealth{
+		ServerID:   resp.Server.ID,
+		ServerName: resp.Server.Name,
+		Healthy:    h.Status == "ok" && h.Error == "",
+		Status:     h.Status,
+		Error:      optionalString(h.Error),
+	}
+}
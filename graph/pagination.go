@@ -0,0 +1,283 @@
+package graph
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"nats-graphql/graph/model"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// maxPageSize is the maximum number of messages returned in one page.
+const maxPageSize = 100
+
+// encodeCursor builds an opaque cursor from a stream sequence number.
+func encodeCursor(seq uint64) string {
+	return base64.StdEncoding.EncodeToString([]byte("seq:" + strconv.FormatUint(seq, 10)))
+}
+
+// decodeCursor extracts the stream sequence number from a cursor.
+func decodeCursor(cursor string) (uint64, error) {
+	raw, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(raw), "seq:") {
+		return 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	seq, err := strconv.ParseUint(strings.TrimPrefix(string(raw), "seq:"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	return seq, nil
+}
+
+// pageReader reads pages of messages from a stream.
+// Without a subject filter page bounds are computed from sequence numbers, as
+// long as no message in the page was deleted. Otherwise matching messages are
+// counted with short-lived consumers: a consumer starting at sequence N reports
+// how many messages matching the filter remain from N to the end of the stream
+// (NumPending), which works for sparse subjects too. Paging backward then costs
+// O(log N) consumers per page.
+type pageReader struct {
+	stream  jetstream.Stream
+	subject string
+}
+
+// open creates an ephemeral consumer starting at seq and returns it together with
+// the number of matching messages from seq to the end of the stream.
+// The caller must delete the consumer.
+func (p *pageReader) open(ctx context.Context, seq uint64) (jetstream.Consumer, uint64, error) {
+	cfg := jetstream.ConsumerConfig{
+		DeliverPolicy:     jetstream.DeliverByStartSequencePolicy,
+		OptStartSeq:       seq,
+		AckPolicy:         jetstream.AckNonePolicy,
+		InactiveThreshold: 30 * time.Second,
+		MemoryStorage:     true,
+	}
+	if p.subject != "" {
+		cfg.FilterSubject = p.subject
+	}
+
+	cons, err := p.stream.CreateConsumer(ctx, cfg)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create consumer: %w", err)
+	}
+	return cons, cons.CachedInfo().NumPending, nil
+}
+
+// remove deletes a consumer created by open. Failures are ignored,
+// the server removes inactive consumers on its own.
+func (p *pageReader) remove(cons jetstream.Consumer) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = p.stream.DeleteConsumer(ctx, cons.CachedInfo().Name)
+}
+
+// count returns the number of matching messages from seq to the end of the stream.
+func (p *pageReader) count(ctx context.Context, seq uint64) (uint64, error) {
+	cons, n, err := p.open(ctx, seq)
+	if err != nil {
+		return 0, err
+	}
+	p.remove(cons)
+	return n, nil
+}
+
+// read fetches up to n messages from cons, skipping messages past maxSeq.
+func (p *pageReader) read(cons jetstream.Consumer, n int, maxSeq uint64) ([]*model.StreamMessage, error) {
+	if n == 0 {
+		return nil, nil
+	}
+
+	msgs, err := cons.Fetch(n, jetstream.FetchMaxWait(3*time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch messages: %w", err)
+	}
+
+	var result []*model.StreamMessage
+	for msg := range msgs.Messages() {
+		meta, err := msg.Metadata()
+		if err != nil {
+			continue
+		}
+		if meta.Sequence.Stream > maxSeq {
+			continue
+		}
+		result = append(result, &model.StreamMessage{
+			Sequence:  int(meta.Sequence.Stream),
+			Subject:   msg.Subject(),
+			Data:      string(msg.Data()),
+			Published: meta.Timestamp.Format(time.RFC3339Nano),
+			Headers:   mapHeaders(msg.Headers()),
+		})
+	}
+
+	// Timeout only means fewer messages were available (e.g. deleted meanwhile)
+	if err := msgs.Error(); err != nil && !errors.Is(err, nats.ErrTimeout) {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// forward returns up to limit messages starting at sequence start.
+func (p *pageReader) forward(ctx context.Context, firstSeq, start uint64, limit int, hasCursor bool) (*model.StreamMessageConnection, error) {
+	cons, pending, err := p.open(ctx, start)
+	if err != nil {
+		return nil, err
+	}
+	defer p.remove(cons)
+
+	n := uint64(limit)
+	if pending < n {
+		n = pending
+	}
+	msgs, err := p.read(cons, int(n), ^uint64(0))
+	if err != nil {
+		return nil, err
+	}
+
+	hasPrev := hasCursor && start > firstSeq
+	if hasPrev && p.subject != "" {
+		total, err := p.count(ctx, firstSeq)
+		if err != nil {
+			return nil, err
+		}
+		hasPrev = total > pending
+	}
+
+	return buildConnection(msgs, pending > n, hasPrev), nil
+}
+
+// backward returns up to limit messages ending at sequence end (inclusive).
+func (p *pageReader) backward(ctx context.Context, firstSeq, lastSeq, end uint64, limit int, hasCursor bool) (*model.StreamMessageConnection, error) {
+	if p.subject == "" {
+		conn, err := p.backwardBySeq(ctx, firstSeq, lastSeq, end, limit)
+		if err != nil || conn != nil {
+			return conn, err
+		}
+		// Deleted messages in the window, search for the start like for a filter
+	}
+
+	// Matching messages after the page
+	after, err := p.count(ctx, end+1)
+	if err != nil {
+		return nil, err
+	}
+	total, err := p.count(ctx, firstSeq)
+	if err != nil {
+		return nil, err
+	}
+	total -= after
+
+	want := uint64(limit)
+	start := firstSeq
+	if total > want {
+		// Find the largest start so that [start, end] holds exactly `want` matching
+		// messages: widen the window from the end, then binary search inside it
+		lo, hi := firstSeq, end
+		for width := want; ; width *= 2 {
+			if end-firstSeq+1 <= width {
+				break
+			}
+			s := end - width + 1
+			c, err := p.count(ctx, s)
+			if err != nil {
+				return nil, err
+			}
+			if c-after >= want {
+				lo = s
+				break
+			}
+			hi = s - 1
+		}
+		for lo < hi {
+			mid := lo + (hi-lo+1)/2
+			c, err := p.count(ctx, mid)
+			if err != nil {
+				return nil, err
+			}
+			if c-after >= want {
+				lo = mid
+			} else {
+				hi = mid - 1
+			}
+		}
+		start = lo
+	}
+
+	cons, pending, err := p.open(ctx, start)
+	if err != nil {
+		return nil, err
+	}
+	defer p.remove(cons)
+
+	n := pending - after
+	if pending < after {
+		n = 0
+	}
+	if n > want {
+		n = want
+	}
+	msgs, err := p.read(cons, int(n), end)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildConnection(msgs, hasCursor && after > 0, total > want), nil
+}
+
+// backwardBySeq returns the limit sequences ending at end (inclusive) without a
+// subject filter. It returns nil if some of them were deleted, since the page
+// would then be short or even empty (without cursors to continue from).
+func (p *pageReader) backwardBySeq(ctx context.Context, firstSeq, lastSeq, end uint64, limit int) (*model.StreamMessageConnection, error) {
+	want := uint64(limit)
+	start := firstSeq
+	if end-firstSeq+1 > want {
+		start = end - want + 1
+	}
+
+	cons, pending, err := p.open(ctx, start)
+	if err != nil {
+		return nil, err
+	}
+	defer p.remove(cons)
+
+	msgs, err := p.read(cons, int(min(end-start+1, pending)), end)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(msgs)) < end-start+1 {
+		return nil, nil
+	}
+
+	return buildConnection(msgs, end < lastSeq, start > firstSeq), nil
+}
+
+// buildConnection wraps messages into a Relay connection.
+func buildConnection(msgs []*model.StreamMessage, hasNext, hasPrev bool) *model.StreamMessageConnection {
+	conn := &model.StreamMessageConnection{
+		Edges: make([]*model.StreamMessageEdge, len(msgs)),
+		PageInfo: &model.PageInfo{
+			HasNextPage:     hasNext,
+			HasPreviousPage: hasPrev,
+		},
+	}
+	for i, msg := range msgs {
+		conn.Edges[i] = &model.StreamMessageEdge{
+			Cursor: encodeCursor(uint64(msg.Sequence)),
+			Node:   msg,
+		}
+	}
+	if len(conn.Edges) > 0 {
+		startCursor := conn.Edges[0].Cursor
+		endCursor := conn.Edges[len(conn.Edges)-1].Cursor
+		conn.PageInfo.StartCursor = &startCursor
+		conn.PageInfo.EndCursor = &endCursor
+	}
+	return conn
+}
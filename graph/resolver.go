@@ -1,6 +1,8 @@
 package graph
 
 import (
+	"nats-graphql/scheduler"
+
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
 )
@@ -9,4 +11,8 @@ import (
 type Resolver struct {
 	NC *nats.Conn
 	JS jetstream.JetStream
+
+	// Scheduler stores and publishes delayed messages (publishScheduled).
+	// Nil when it could not be started, scheduling then fails with an error.
+	Scheduler *scheduler.Scheduler
 }
@@ -7,15 +7,28 @@ package graph
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"nats-graphql/graph/model"
+	"sort"
 	"time"
 
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
+	"github.com/nats-io/nats.go/micro"
 )
 
+// Data is the resolver for the data field.
+func (r *coreMessageResolver) Data(ctx context.Context, obj *model.CoreMessage, encoding *model.Encoding) (string, error) {
+	return encodePayload([]byte(obj.Data), encoding), nil
+}
+
+// Value is the resolver for the value field.
+func (r *kVEntryResolver) Value(ctx context.Context, obj *model.KVEntry, encoding *model.Encoding) (string, error) {
+	return encodePayload([]byte(obj.Value), encoding), nil
+}
+
 // KvCreate is the resolver for the kvCreate field.
 func (r *mutationResolver) KvCreate(ctx context.Context, bucket string, history *int, ttl *int, storage *string) (*model.KeyValue, error) {
 	cfg := jetstream.KeyValueConfig{
@@ -60,13 +73,18 @@ func (r *mutationResolver) KvCreate(ctx context.Context, bucket string, history
 }
 
 // KvPut is the resolver for the kvPut field.
-func (r *mutationResolver) KvPut(ctx context.Context, bucket string, key string, value string) (*model.KVEntry, error) {
+func (r *mutationResolver) KvPut(ctx context.Context, bucket string, key string, value string, encoding *model.Encoding) (*model.KVEntry, error) {
+	payload, err := decodePayload(value, encoding)
+	if err != nil {
+		return nil, err
+	}
+
 	kv, err := r.JS.KeyValue(ctx, bucket)
 	if err != nil {
 		return nil, err
 	}
 
-	rev, err := kv.Put(ctx, key, []byte(value))
+	rev, err := kv.Put(ctx, key, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -77,12 +95,99 @@ func (r *mutationResolver) KvPut(ctx context.Context, bucket string, key string,
 		return nil, err
 	}
 
-	return &model.KVEntry{
-		Key:      entry.Key(),
-		Value:    string(entry.Value()),
-		Revision: int(entry.Revision()),
-		Created:  entry.Created().Format(time.RFC3339),
-	}, nil
+	return mapKVEntry(entry), nil
+}
+
+// KvCreateKey is the resolver for the kvCreateKey field.
+func (r *mutationResolver) KvCreateKey(ctx context.Context, bucket string, key string, value string, encoding *model.Encoding) (*model.KVEntry, error) {
+	payload, err := decodePayload(value, encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	kv, err := r.JS.KeyValue(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	rev, err := kv.Create(ctx, key, payload)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrKeyExists) {
+			return nil, codedError(errCodeKeyExists, fmt.Errorf("key %q already exists", key))
+		}
+		return nil, err
+	}
+
+	entry, err := kv.GetRevision(ctx, key, rev)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapKVEntry(entry), nil
+}
+
+// KvUpdateKey is the resolver for the kvUpdateKey field.
+func (r *mutationResolver) KvUpdateKey(ctx context.Context, bucket string, key string, value string, revision int, encoding *model.Encoding) (*model.KVEntry, error) {
+	if revision < 0 {
+		return nil, fmt.Errorf("revision must be >= 0")
+	}
+
+	payload, err := decodePayload(value, encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	kv, err := r.JS.KeyValue(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	rev, err := kv.Update(ctx, key, payload, uint64(revision))
+	if err != nil {
+		if errors.Is(err, jetstream.ErrKeyExists) {
+			return nil, codedError(errCodeRevisionMismatch, fmt.Errorf("key %q was modified: expected revision %d", key, revision))
+		}
+		return nil, err
+	}
+
+	entry, err := kv.GetRevision(ctx, key, rev)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapKVEntry(entry), nil
+}
+
+// KvRevert is the resolver for the kvRevert field.
+func (r *mutationResolver) KvRevert(ctx context.Context, bucket string, key string, revision int) (*model.KVEntry, error) {
+	if revision < 1 {
+		return nil, fmt.Errorf("revision must be >= 1")
+	}
+
+	kv, err := r.JS.KeyValue(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	old, err := kv.GetRevision(ctx, key, uint64(revision))
+	if err != nil {
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			return nil, fmt.Errorf("revision %d of key %q not found (deleted or no longer retained)", revision, key)
+		}
+		return nil, err
+	}
+
+	rev, err := kv.Put(ctx, key, old.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := kv.GetRevision(ctx, key, rev)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapKVEntry(entry), nil
 }
 
 // KvDelete is the resolver for the kvDelete field.
@@ -174,6 +279,104 @@ func (r *mutationResolver) KvUpdate(ctx context.Context, bucket string, history
 	}, nil
 }
 
+// ObjectStoreCreate is the resolver for the objectStoreCreate field.
+func (r *mutationResolver) ObjectStoreCreate(ctx context.Context, bucket string, description *string, ttl *int, storage *string, replicas *int, maxBytes *int, compression *bool) (*model.ObjectStore, error) {
+	cfg := jetstream.ObjectStoreConfig{
+		Bucket: bucket,
+	}
+
+	if description != nil {
+		cfg.Description = *description
+	}
+	if ttl != nil {
+		cfg.TTL = time.Duration(*ttl) * time.Second
+	}
+	if storage != nil {
+		switch *storage {
+		case "memory":
+			cfg.Storage = jetstream.MemoryStorage
+		default:
+			cfg.Storage = jetstream.FileStorage
+		}
+	}
+	if replicas != nil {
+		cfg.Replicas = *replicas
+	}
+	if maxBytes != nil {
+		cfg.MaxBytes = int64(*maxBytes)
+	}
+	if compression != nil {
+		cfg.Compression = *compression
+	}
+
+	obs, err := r.JS.CreateObjectStore(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := obs.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapObjectStore(status), nil
+}
+
+// ObjectStoreDelete is the resolver for the objectStoreDelete field.
+func (r *mutationResolver) ObjectStoreDelete(ctx context.Context, bucket string) (bool, error) {
+	err := r.JS.DeleteObjectStore(ctx, bucket)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ObjectDelete is the resolver for the objectDelete field.
+func (r *mutationResolver) ObjectDelete(ctx context.Context, bucket string, name string) (bool, error) {
+	obs, err := r.JS.ObjectStore(ctx, bucket)
+	if err != nil {
+		return false, err
+	}
+
+	if err := obs.Delete(ctx, name); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ObjectLink is the resolver for the objectLink field.
+func (r *mutationResolver) ObjectLink(ctx context.Context, bucket string, name string, targetBucket string, targetName *string) (*model.ObjectInfo, error) {
+	obs, err := r.JS.ObjectStore(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	target := obs
+	if targetBucket != bucket {
+		target, err = r.JS.ObjectStore(ctx, targetBucket)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var info *jetstream.ObjectInfo
+	if targetName == nil || *targetName == "" {
+		info, err = obs.AddBucketLink(ctx, name, target)
+	} else {
+		var targetInfo *jetstream.ObjectInfo
+		targetInfo, err = target.GetInfo(ctx, *targetName)
+		if err != nil {
+			return nil, fmt.Errorf("link target %s/%s: %w", targetBucket, *targetName, err)
+		}
+		info, err = obs.AddLink(ctx, name, targetInfo)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return mapObjectInfo(info), nil
+}
+
 // StreamCreate is the resolver for the streamCreate field.
 func (r *mutationResolver) StreamCreate(ctx context.Context, name string, subjects []string, retention *string, storage *string, maxConsumers *int, maxMsgs *int, maxBytes *int, maxAge *int, replicas *int) (*model.StreamInfo, error) {
 	cfg := jetstream.StreamConfig{
@@ -217,24 +420,7 @@ func (r *mutationResolver) StreamCreate(ctx context.Context, name string, subjec
 		return nil, err
 	}
 
-	info := si.CachedInfo()
-
-	return &model.StreamInfo{
-		Name:         info.Config.Name,
-		Subjects:     info.Config.Subjects,
-		Retention:    info.Config.Retention.String(),
-		MaxConsumers: info.Config.MaxConsumers,
-		MaxMsgs:      int(info.Config.MaxMsgs),
-		MaxBytes:     int(info.Config.MaxBytes),
-		MaxAge:       int(info.Config.MaxAge / time.Second),
-		Storage:      info.Config.Storage.String(),
-		Replicas:     info.Config.Replicas,
-		Messages:     int(info.State.Msgs),
-		Bytes:        int(info.State.Bytes),
-		Consumers:    info.State.Consumers,
-		Created:      info.Created.Format(time.RFC3339),
-		Sources:      mapSources(info.Sources),
-	}, nil
+	return mapStreamInfo(si.CachedInfo()), nil
 }
 
 // StreamDelete is the resolver for the streamDelete field.
@@ -247,16 +433,61 @@ func (r *mutationResolver) StreamDelete(ctx context.Context, name string) (bool,
 }
 
 // StreamPurge is the resolver for the streamPurge field.
-func (r *mutationResolver) StreamPurge(ctx context.Context, name string, subject *string) (bool, error) {
-	s, err := r.JS.Stream(ctx, name)
+func (r *mutationResolver) StreamPurge(ctx context.Context, name string, subject *string, keep *int, upToSeq *int) (int, error) {
+	if keep != nil && upToSeq != nil {
+		return 0, fmt.Errorf("keep and upToSeq cannot be combined")
+	}
+
+	var req jetstream.StreamPurgeRequest
+	if subject != nil && *subject != "" {
+		req.Subject = *subject
+	}
+	if keep != nil {
+		if *keep < 1 {
+			return 0, fmt.Errorf("keep must be >= 1")
+		}
+		req.Keep = uint64(*keep)
+	}
+	if upToSeq != nil {
+		if *upToSeq < 1 {
+			return 0, fmt.Errorf("upToSeq must be >= 1")
+		}
+		req.Sequence = uint64(*upToSeq)
+	}
+
+	// Stream.Purge does not report how many messages were removed,
+	// so call the purge API directly
+	var resp struct {
+		Error  *jetstream.APIError `json:"error,omitempty"`
+		Purged uint64              `json:"purged"`
+	}
+	if err := r.jsAPIRequest(ctx, "STREAM.PURGE."+name, req, &resp); err != nil {
+		return 0, err
+	}
+	if resp.Error != nil {
+		return 0, resp.Error
+	}
+
+	return int(resp.Purged), nil
+}
+
+// StreamDeleteMessage is the resolver for the streamDeleteMessage field.
+func (r *mutationResolver) StreamDeleteMessage(ctx context.Context, stream string, seq int, erase *bool) (bool, error) {
+	if seq < 1 {
+		return false, fmt.Errorf("seq must be >= 1")
+	}
+
+	s, err := r.JS.Stream(ctx, stream)
 	if err != nil {
 		return false, err
 	}
-	var opts []jetstream.StreamPurgeOpt
-	if subject != nil && *subject != "" {
-		opts = append(opts, jetstream.WithPurgeSubject(*subject))
+
+	if erase != nil && *erase {
+		err = s.SecureDeleteMsg(ctx, uint64(seq))
+	} else {
+		err = s.DeleteMsg(ctx, uint64(seq))
 	}
-	if err := s.Purge(ctx, opts...); err != nil {
+	if err != nil {
 		return false, err
 	}
 	return true, nil
@@ -300,24 +531,7 @@ func (r *mutationResolver) StreamUpdate(ctx context.Context, name string, subjec
 		return nil, err
 	}
 
-	info := si.CachedInfo()
-
-	return &model.StreamInfo{
-		Name:         info.Config.Name,
-		Subjects:     info.Config.Subjects,
-		Retention:    info.Config.Retention.String(),
-		MaxConsumers: info.Config.MaxConsumers,
-		MaxMsgs:      int(info.Config.MaxMsgs),
-		MaxBytes:     int(info.Config.MaxBytes),
-		MaxAge:       int(info.Config.MaxAge / time.Second),
-		Storage:      info.Config.Storage.String(),
-		Replicas:     info.Config.Replicas,
-		Messages:     int(info.State.Msgs),
-		Bytes:        int(info.State.Bytes),
-		Consumers:    info.State.Consumers,
-		Created:      info.Created.Format(time.RFC3339),
-		Sources:      mapSources(info.Sources),
-	}, nil
+	return mapStreamInfo(si.CachedInfo()), nil
 }
 
 // StreamCopy is the resolver for the streamCopy field.
@@ -383,44 +597,14 @@ func (r *mutationResolver) StreamCopy(ctx context.Context, name string, sources
 		return nil, err
 	}
 
-	info := si.CachedInfo()
-
-	return &model.StreamInfo{
-		Name:         info.Config.Name,
-		Subjects:     info.Config.Subjects,
-		Retention:    info.Config.Retention.String(),
-		MaxConsumers: info.Config.MaxConsumers,
-		MaxMsgs:      int(info.Config.MaxMsgs),
-		MaxBytes:     int(info.Config.MaxBytes),
-		MaxAge:       int(info.Config.MaxAge / time.Second),
-		Storage:      info.Config.Storage.String(),
-		Replicas:     info.Config.Replicas,
-		Messages:     int(info.State.Msgs),
-		Bytes:        int(info.State.Bytes),
-		Consumers:    info.State.Consumers,
-		Created:      info.Created.Format(time.RFC3339),
-		Sources:      mapSources(info.Sources),
-	}, nil
+	return mapStreamInfo(si.CachedInfo()), nil
 }
 
 // Publish is the resolver for the publish field.
-func (r *mutationResolver) Publish(ctx context.Context, subject string, data string, headers *string) (*model.PublishResult, error) {
-	const maxPayload = 1 << 20 // 1 MB
-	if len(data) > maxPayload {
-		return nil, fmt.Errorf("payload too large: %d bytes (max %d)", len(data), maxPayload)
-	}
-
-	msg := &nats.Msg{
-		Subject: subject,
-		Data:    []byte(data),
-	}
-
-	if headers != nil && *headers != "" {
-		h, err := parseHeaders(*headers)
-		if err != nil {
-			return nil, err
-		}
-		msg.Header = h
+func (r *mutationResolver) Publish(ctx context.Context, subject string, data string, headers *string, encoding *model.Encoding) (*model.PublishResult, error) {
+	msg, err := buildMessage(subject, data, headers, encoding)
+	if err != nil {
+		return nil, err
 	}
 
 	ack, err := r.JS.PublishMsg(ctx, msg)
@@ -434,104 +618,334 @@ func (r *mutationResolver) Publish(ctx context.Context, subject string, data str
 	}, nil
 }
 
-// PublishScheduled is the resolver for the publishScheduled field.
-func (r *mutationResolver) PublishScheduled(ctx context.Context, subject string, data string, delay int, headers *string) (bool, error) {
-	const maxPayload = 1 << 20 // 1 MB
-	if len(data) > maxPayload {
-		return false, fmt.Errorf("payload too large: %d bytes (max %d)", len(data), maxPayload)
-	}
-	if delay <= 0 {
-		return false, fmt.Errorf("delay must be positive, got %d", delay)
+// CorePublish is the resolver for the corePublish field.
+func (r *mutationResolver) CorePublish(ctx context.Context, subject string, data string, headers *string, encoding *model.Encoding) (bool, error) {
+	msg, err := buildMessage(subject, data, headers, encoding)
+	if err != nil {
+		return false, err
 	}
 
-	// Parse headers before launching goroutine to return errors immediately
-	var h nats.Header
-	if headers != nil && *headers != "" {
-		var err error
-		h, err = parseHeaders(*headers)
-		if err != nil {
-			return false, err
-		}
+	if err := r.NC.PublishMsg(msg); err != nil {
+		return false, err
+	}
+	// Flush so true means the server has received the message. Permission violations
+	// are reported asynchronously and do not fail the flush
+	flushCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := r.NC.FlushWithContext(flushCtx); err != nil {
+		return false, err
 	}
-
-	go func() {
-		time.Sleep(time.Duration(delay) * time.Second)
-		bgCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-		msg := &nats.Msg{
-			Subject: subject,
-			Data:    []byte(data),
-			Header:  h,
-		}
-		_, err := r.JS.PublishMsg(bgCtx, msg)
-		if err != nil {
-			fmt.Printf("scheduled publish to %s failed: %v\n", subject, err)
-		}
-	}()
 
 	return true, nil
 }
 
-// ConsumerCreate is the resolver for the consumerCreate field.
-func (r *mutationResolver) ConsumerCreate(ctx context.Context, stream string, name string, filterSubject *string, filterSubjects []string, deliverPolicy *string, ackPolicy *string, ackWait *int, maxDeliver *int, maxAckPending *int, replicas *int, description *string) (*model.ConsumerInfo, error) {
-	cfg := jetstream.ConsumerConfig{
-		Name:    name,
-		Durable: name,
-	}
-
-	if filterSubject != nil && *filterSubject != "" {
-		cfg.FilterSubject = *filterSubject
+// Request is the resolver for the request field.
+func (r *mutationResolver) Request(ctx context.Context, subject string, data string, headers *string, timeout *int, encoding *model.Encoding) (*model.CoreMessage, error) {
+	msg, err := buildMessage(subject, data, headers, encoding)
+	if err != nil {
+		return nil, err
 	}
-	if len(filterSubjects) > 0 {
-		cfg.FilterSubjects = filterSubjects
+	wait, err := requestWait(timeout)
+	if err != nil {
+		return nil, err
 	}
 
-	if deliverPolicy != nil {
-		switch *deliverPolicy {
-		case "last":
-			cfg.DeliverPolicy = jetstream.DeliverLastPolicy
-		case "new":
-			cfg.DeliverPolicy = jetstream.DeliverNewPolicy
-		case "last_per_subject":
-			cfg.DeliverPolicy = jetstream.DeliverLastPerSubjectPolicy
-		default:
-			cfg.DeliverPolicy = jetstream.DeliverAllPolicy
+	// The request is cancelled together with the GraphQL operation
+	ctx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+	reply, err := r.NC.RequestMsgWithContext(ctx, msg)
+	if err != nil {
+		if errors.Is(err, nats.ErrNoResponders) {
+			return nil, fmt.Errorf("no responders on subject %q", subject)
 		}
-	}
-
-	if ackPolicy != nil {
-		switch *ackPolicy {
-		case "none":
-			cfg.AckPolicy = jetstream.AckNonePolicy
-		case "all":
-			cfg.AckPolicy = jetstream.AckAllPolicy
-		default:
-			cfg.AckPolicy = jetstream.AckExplicitPolicy
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("no reply on subject %q within %s", subject, wait)
 		}
+		return nil, err
 	}
 
-	if ackWait != nil {
-		cfg.AckWait = time.Duration(*ackWait) * time.Second
-	}
-	if maxDeliver != nil {
-		cfg.MaxDeliver = *maxDeliver
-	}
-	if maxAckPending != nil {
-		cfg.MaxAckPending = *maxAckPending
-	}
-	if replicas != nil {
-		cfg.Replicas = *replicas
-	}
-	if description != nil {
-		cfg.Description = *description
-	}
+	return mapCoreMessage(reply), nil
+}
 
-	cons, err := r.JS.CreateOrUpdateConsumer(ctx, stream, cfg)
+// ServiceInvoke is the resolver for the serviceInvoke field.
+func (r *mutationResolver) ServiceInvoke(ctx context.Context, service string, endpoint string, data string, headers *string, timeout *int, encoding *model.Encoding) (*model.CoreMessage, error) {
+	msg, err := buildMessage("", data, headers, encoding)
 	if err != nil {
 		return nil, err
 	}
-
-	ci, err := cons.Info(ctx)
+	wait, err := requestWait(timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// One deadline covers both the endpoint lookup and the request
+	ctx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+
+	// Any instance can tell us the endpoint subject, they all share the same endpoints
+	infoSubject, err := micro.ControlSubject(micro.InfoVerb, service, "")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.NC.RequestWithContext(ctx, infoSubject, nil)
+	if err != nil {
+		if errors.Is(err, nats.ErrNoResponders) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("service %q not found", service)
+		}
+		return nil, err
+	}
+	var info micro.Info
+	if err := json.Unmarshal(resp.Data, &info); err != nil {
+		return nil, fmt.Errorf("invalid info reply from service %q: %w", service, err)
+	}
+
+	for _, ep := range info.Endpoints {
+		if ep.Name == endpoint {
+			msg.Subject = ep.Subject
+			break
+		}
+	}
+	if msg.Subject == "" {
+		return nil, fmt.Errorf("endpoint %q not found on service %q", endpoint, service)
+	}
+
+	reply, err := r.NC.RequestMsgWithContext(ctx, msg)
+	if err != nil {
+		if errors.Is(err, nats.ErrNoResponders) {
+			return nil, fmt.Errorf("no responders on subject %q", msg.Subject)
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("no reply from service %q within %s", service, wait)
+		}
+		return nil, err
+	}
+
+	if desc := reply.Header.Get(micro.ErrorHeader); desc != "" {
+		return nil, codedError(errCodeServiceError, fmt.Errorf("service error %s: %s", reply.Header.Get(micro.ErrorCodeHeader), desc))
+	}
+
+	return mapCoreMessage(reply), nil
+}
+
+// PublishScheduled is the resolver for the publishScheduled field.
+func (r *mutationResolver) PublishScheduled(ctx context.Context, subject string, data string, delay int, headers *string, encoding *model.Encoding) (string, error) {
+	sched, err := r.requireScheduler()
+	if err != nil {
+		return "", err
+	}
+
+	if delay <= 0 {
+		return "", fmt.Errorf("delay must be positive, got %d", delay)
+	}
+
+	payload, h, err := r.prepareScheduled(ctx, subject, data, headers, encoding)
+	if err != nil {
+		return "", err
+	}
+
+	msg, err := sched.Schedule(ctx, subject, payload, h, time.Now().Add(time.Duration(delay)*time.Second))
+	if err != nil {
+		return "", err
+	}
+
+	return msg.ID, nil
+}
+
+// ScheduledCancel is the resolver for the scheduledCancel field.
+func (r *mutationResolver) ScheduledCancel(ctx context.Context, id string) (bool, error) {
+	sched, err := r.requireScheduler()
+	if err != nil {
+		return false, err
+	}
+
+	if err := sched.Cancel(ctx, id, false); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ScheduledReschedule is the resolver for the scheduledReschedule field.
+func (r *mutationResolver) ScheduledReschedule(ctx context.Context, id string, delay int) (*model.ScheduledMessage, error) {
+	sched, err := r.requireScheduler()
+	if err != nil {
+		return nil, err
+	}
+
+	if delay <= 0 {
+		return nil, fmt.Errorf("delay must be positive, got %d", delay)
+	}
+
+	msg, err := sched.Reschedule(ctx, id, time.Now().Add(time.Duration(delay)*time.Second))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapScheduledMessage(msg), nil
+}
+
+// PublishRecurring is the resolver for the publishRecurring field.
+func (r *mutationResolver) PublishRecurring(ctx context.Context, subject string, data string, cron string, headers *string, timezone *string, encoding *model.Encoding) (*model.RecurringMessage, error) {
+	sched, err := r.requireScheduler()
+	if err != nil {
+		return nil, err
+	}
+
+	payload, h, err := r.prepareScheduled(ctx, subject, data, headers, encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	tz := "UTC"
+	if timezone != nil && *timezone != "" {
+		tz = *timezone
+	}
+
+	msg, err := sched.ScheduleRecurring(ctx, subject, payload, h, cron, tz)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapRecurringMessage(msg), nil
+}
+
+// RecurringPause is the resolver for the recurringPause field.
+func (r *mutationResolver) RecurringPause(ctx context.Context, id string) (*model.RecurringMessage, error) {
+	sched, err := r.requireScheduler()
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := sched.SetPaused(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	return mapRecurringMessage(msg), nil
+}
+
+// RecurringResume is the resolver for the recurringResume field.
+func (r *mutationResolver) RecurringResume(ctx context.Context, id string) (*model.RecurringMessage, error) {
+	sched, err := r.requireScheduler()
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := sched.SetPaused(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	return mapRecurringMessage(msg), nil
+}
+
+// RecurringDelete is the resolver for the recurringDelete field.
+func (r *mutationResolver) RecurringDelete(ctx context.Context, id string) (bool, error) {
+	sched, err := r.requireScheduler()
+	if err != nil {
+		return false, err
+	}
+
+	if err := sched.Cancel(ctx, id, true); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ConsumerCreate is the resolver for the consumerCreate field.
+func (r *mutationResolver) ConsumerCreate(ctx context.Context, stream string, name string, filterSubject *string, filterSubjects []string, deliverPolicy *string, ackPolicy *string, ackWait *int, maxDeliver *int, maxAckPending *int, replicas *int, description *string, backoff []string, inactiveThreshold *string, maxRequestBatch *int, maxRequestExpires *string, headersOnly *bool, memoryStorage *bool, metadata *string) (*model.ConsumerInfo, error) {
+	cfg := jetstream.ConsumerConfig{
+		Name:    name,
+		Durable: name,
+	}
+
+	if filterSubject != nil && *filterSubject != "" {
+		cfg.FilterSubject = *filterSubject
+	}
+	if len(filterSubjects) > 0 {
+		cfg.FilterSubjects = filterSubjects
+	}
+
+	if deliverPolicy != nil {
+		switch *deliverPolicy {
+		case "last":
+			cfg.DeliverPolicy = jetstream.DeliverLastPolicy
+		case "new":
+			cfg.DeliverPolicy = jetstream.DeliverNewPolicy
+		case "last_per_subject":
+			cfg.DeliverPolicy = jetstream.DeliverLastPerSubjectPolicy
+		default:
+			cfg.DeliverPolicy = jetstream.DeliverAllPolicy
+		}
+	}
+
+	if ackPolicy != nil {
+		switch *ackPolicy {
+		case "none":
+			cfg.AckPolicy = jetstream.AckNonePolicy
+		case "all":
+			cfg.AckPolicy = jetstream.AckAllPolicy
+		default:
+			cfg.AckPolicy = jetstream.AckExplicitPolicy
+		}
+	}
+
+	if ackWait != nil {
+		cfg.AckWait = time.Duration(*ackWait) * time.Second
+	}
+	if maxDeliver != nil {
+		cfg.MaxDeliver = *maxDeliver
+	}
+	if maxAckPending != nil {
+		cfg.MaxAckPending = *maxAckPending
+	}
+	if replicas != nil {
+		cfg.Replicas = *replicas
+	}
+	if description != nil {
+		cfg.Description = *description
+	}
+	if len(backoff) > 0 {
+		d, err := parseBackoff(backoff)
+		if err != nil {
+			return nil, err
+		}
+		cfg.BackOff = d
+	}
+	if inactiveThreshold != nil {
+		d, err := parseDuration("inactiveThreshold", *inactiveThreshold)
+		if err != nil {
+			return nil, err
+		}
+		cfg.InactiveThreshold = d
+	}
+	if maxRequestBatch != nil {
+		cfg.MaxRequestBatch = *maxRequestBatch
+	}
+	if maxRequestExpires != nil {
+		d, err := parseDuration("maxRequestExpires", *maxRequestExpires)
+		if err != nil {
+			return nil, err
+		}
+		cfg.MaxRequestExpires = d
+	}
+	if headersOnly != nil {
+		cfg.HeadersOnly = *headersOnly
+	}
+	if memoryStorage != nil {
+		cfg.MemoryStorage = *memoryStorage
+	}
+	if metadata != nil && *metadata != "" {
+		md, err := parseMetadata(*metadata)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Metadata = md
+	}
+
+	cons, err := r.JS.CreateOrUpdateConsumer(ctx, stream, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ci, err := cons.Info(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -539,6 +953,141 @@ func (r *mutationResolver) ConsumerCreate(ctx context.Context, stream string, na
 	return mapConsumerInfo(ci), nil
 }
 
+// ConsumerUpdate is the resolver for the consumerUpdate field.
+func (r *mutationResolver) ConsumerUpdate(ctx context.Context, stream string, name string, ackWait *int, maxDeliver *int, maxAckPending *int, filterSubjects []string, description *string, backoff []string, sampleFrequency *string) (*model.ConsumerInfo, error) {
+	s, err := r.JS.Stream(ctx, stream)
+	if err != nil {
+		return nil, err
+	}
+
+	cons, err := s.Consumer(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := cons.CachedInfo().Config
+
+	if ackWait != nil {
+		cfg.AckWait = time.Duration(*ackWait) * time.Second
+	}
+	if maxDeliver != nil {
+		cfg.MaxDeliver = *maxDeliver
+	}
+	if maxAckPending != nil {
+		cfg.MaxAckPending = *maxAckPending
+	}
+	if len(filterSubjects) > 0 {
+		cfg.FilterSubject = ""
+		cfg.FilterSubjects = filterSubjects
+	}
+	if description != nil {
+		cfg.Description = *description
+	}
+	// An empty list is meaningful here: it removes the backoff schedule
+	if backoff != nil {
+		d, err := parseBackoff(backoff)
+		if err != nil {
+			return nil, err
+		}
+		cfg.BackOff = d
+	}
+	if sampleFrequency != nil {
+		cfg.SampleFrequency = *sampleFrequency
+	}
+
+	updated, err := s.UpdateConsumer(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapConsumerInfo(updated.CachedInfo()), nil
+}
+
+// ConsumerFetch is the resolver for the consumerFetch field.
+func (r *mutationResolver) ConsumerFetch(ctx context.Context, stream string, consumer string, batch *int, maxWait *int) ([]*model.ConsumerMessage, error) {
+	const (
+		maxBatch   = 100
+		maxWaitCap = 30
+	)
+	n, wait := 10, 5
+	if batch != nil {
+		n = *batch
+	}
+	if maxWait != nil {
+		wait = *maxWait
+	}
+	if n <= 0 || n > maxBatch {
+		return nil, fmt.Errorf("batch must be between 1 and %d", maxBatch)
+	}
+	if wait <= 0 || wait > maxWaitCap {
+		return nil, fmt.Errorf("maxWait must be between 1 and %d seconds", maxWaitCap)
+	}
+
+	cons, err := r.JS.Consumer(ctx, stream, consumer)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, err := cons.Fetch(n, jetstream.FetchMaxWait(time.Duration(wait)*time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch messages: %w", err)
+	}
+
+	result := []*model.ConsumerMessage{}
+	for msg := range msgs.Messages() {
+		meta, err := msg.Metadata()
+		if err != nil {
+			continue
+		}
+		result = append(result, &model.ConsumerMessage{
+			Token: encodeAckToken(msg.Reply()),
+			Message: &model.StreamMessage{
+				Sequence:  int(meta.Sequence.Stream),
+				Subject:   msg.Subject(),
+				Data:      string(msg.Data()),
+				Published: meta.Timestamp.Format(time.RFC3339Nano),
+				Headers:   mapHeaders(msg.Headers()),
+			},
+			DeliveryCount: int(meta.NumDelivered),
+			Pending:       int(meta.NumPending),
+		})
+	}
+
+	// Timeout just means fewer messages were available than requested
+	if err := msgs.Error(); err != nil && !errors.Is(err, nats.ErrTimeout) {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// MessageAck is the resolver for the messageAck field.
+func (r *mutationResolver) MessageAck(ctx context.Context, token string) (bool, error) {
+	return r.ackMessage(ctx, token, []byte("+ACK"))
+}
+
+// MessageNak is the resolver for the messageNak field.
+func (r *mutationResolver) MessageNak(ctx context.Context, token string, delay *int) (bool, error) {
+	payload := []byte("-NAK")
+	if delay != nil {
+		if *delay < 0 {
+			return false, fmt.Errorf("delay must be >= 0")
+		}
+		payload = fmt.Appendf(nil, `-NAK {"delay": %d}`, time.Duration(*delay)*time.Second)
+	}
+	return r.ackMessage(ctx, token, payload)
+}
+
+// MessageTerm is the resolver for the messageTerm field.
+func (r *mutationResolver) MessageTerm(ctx context.Context, token string) (bool, error) {
+	return r.ackMessage(ctx, token, []byte("+TERM"))
+}
+
+// MessageInProgress is the resolver for the messageInProgress field.
+func (r *mutationResolver) MessageInProgress(ctx context.Context, token string) (bool, error) {
+	return r.ackMessage(ctx, token, []byte("+WPI"))
+}
+
 // ConsumerDelete is the resolver for the consumerDelete field.
 func (r *mutationResolver) ConsumerDelete(ctx context.Context, stream string, name string) (bool, error) {
 	err := r.JS.DeleteConsumer(ctx, stream, name)
@@ -615,25 +1164,7 @@ func (r *queryResolver) Streams(ctx context.Context) ([]*model.StreamInfo, error
 
 	streams := r.JS.ListStreams(ctx)
 	for si := range streams.Info() {
-		subjects := make([]string, 0, len(si.Config.Subjects))
-		subjects = append(subjects, si.Config.Subjects...)
-
-		result = append(result, &model.StreamInfo{
-			Name:         si.Config.Name,
-			Subjects:     subjects,
-			Retention:    si.Config.Retention.String(),
-			MaxConsumers: si.Config.MaxConsumers,
-			MaxMsgs:      int(si.Config.MaxMsgs),
-			MaxBytes:     int(si.Config.MaxBytes),
-			MaxAge:       int(si.Config.MaxAge / time.Second),
-			Storage:      si.Config.Storage.String(),
-			Replicas:     si.Config.Replicas,
-			Messages:     int(si.State.Msgs),
-			Bytes:        int(si.State.Bytes),
-			Consumers:    si.State.Consumers,
-			Created:      si.Created.Format(time.RFC3339),
-			Sources:      mapSources(si.Sources),
-		})
+		result = append(result, mapStreamInfo(si))
 	}
 
 	if err := streams.Err(); err != nil {
@@ -643,6 +1174,33 @@ func (r *queryResolver) Streams(ctx context.Context) ([]*model.StreamInfo, error
 	return result, nil
 }
 
+// Stream is the resolver for the stream field.
+func (r *queryResolver) Stream(ctx context.Context, name string) (*model.StreamInfo, error) {
+	s, err := r.JS.Stream(ctx, name)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrStreamNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	info, err := s.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapStreamInfo(info), nil
+}
+
+// AccountInfo is the resolver for the accountInfo field.
+func (r *queryResolver) AccountInfo(ctx context.Context) (*model.AccountInfo, error) {
+	info, err := r.JS.AccountInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return mapAccountInfo(info), nil
+}
+
 // KvKeys is the resolver for the kvKeys field.
 func (r *queryResolver) KvKeys(ctx context.Context, bucket string) ([]string, error) {
 	kv, err := r.JS.KeyValue(ctx, bucket)
@@ -664,13 +1222,22 @@ func (r *queryResolver) KvKeys(ctx context.Context, bucket string) ([]string, er
 }
 
 // KvGet is the resolver for the kvGet field.
-func (r *queryResolver) KvGet(ctx context.Context, bucket string, key string) (*model.KVEntry, error) {
+func (r *queryResolver) KvGet(ctx context.Context, bucket string, key string, revision *int) (*model.KVEntry, error) {
+	if revision != nil && *revision < 1 {
+		return nil, fmt.Errorf("revision must be >= 1")
+	}
+
 	kv, err := r.JS.KeyValue(ctx, bucket)
 	if err != nil {
 		return nil, err
 	}
 
-	entry, err := kv.Get(ctx, key)
+	var entry jetstream.KeyValueEntry
+	if revision != nil {
+		entry, err = kv.GetRevision(ctx, key, uint64(*revision))
+	} else {
+		entry, err = kv.Get(ctx, key)
+	}
 	if err != nil {
 		if errors.Is(err, jetstream.ErrKeyNotFound) {
 			return nil, nil
@@ -678,12 +1245,39 @@ func (r *queryResolver) KvGet(ctx context.Context, bucket string, key string) (*
 		return nil, err
 	}
 
-	return &model.KVEntry{
-		Key:      entry.Key(),
-		Value:    string(entry.Value()),
-		Revision: int(entry.Revision()),
-		Created:  entry.Created().Format(time.RFC3339),
-	}, nil
+	return mapKVEntry(entry), nil
+}
+
+// KvHistory is the resolver for the kvHistory field.
+func (r *queryResolver) KvHistory(ctx context.Context, bucket string, key string, limit *int) ([]*model.KVEntry, error) {
+	if limit != nil && *limit <= 0 {
+		return nil, fmt.Errorf("limit must be > 0")
+	}
+
+	kv, err := r.JS.KeyValue(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := kv.History(ctx, key)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			return []*model.KVEntry{}, nil
+		}
+		return nil, err
+	}
+
+	// Keep only the most recent revisions
+	if limit != nil && len(entries) > *limit {
+		entries = entries[len(entries)-*limit:]
+	}
+
+	result := make([]*model.KVEntry, len(entries))
+	for i, entry := range entries {
+		result[i] = mapKVEntry(entry)
+	}
+
+	return result, nil
 }
 
 // StreamMessages is the resolver for the streamMessages field.
@@ -766,119 +1360,554 @@ func (r *queryResolver) StreamMessages(ctx context.Context, stream string, last
 		}
 	}
 
-	// Create ordered consumer (ephemeral, auto-cleanup)
-	cons, err := s.OrderedConsumer(ctx, cfg)
+	// Create ordered consumer (ephemeral, auto-cleanup)
+	cons, err := s.OrderedConsumer(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consumer: %w", err)
+	}
+
+	// Fetch messages — read up to maxMessages to handle sparse sequences
+	fetchCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	fetchCount := last
+	if startSeq == nil && startTime == nil && info.State.Msgs <= maxMessages {
+		// Read all messages so we can trim to the actual last N
+		fetchCount = int(info.State.Msgs)
+	}
+
+	msgs, err := cons.Fetch(fetchCount, jetstream.FetchMaxWait(3*time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch messages: %w", err)
+	}
+
+	var result []*model.StreamMessage
+loop:
+	for msg := range msgs.Messages() {
+		select {
+		case <-fetchCtx.Done():
+			break loop
+		default:
+		}
+
+		meta, err := msg.Metadata()
+		if err != nil {
+			continue
+		}
+
+		msgTime := meta.Timestamp
+
+		// Apply endTime filter
+		if hasEndTime && msgTime.After(endTimeVal) {
+			break
+		}
+
+		result = append(result, &model.StreamMessage{
+			Sequence:  int(meta.Sequence.Stream),
+			Subject:   msg.Subject(),
+			Data:      string(msg.Data()),
+			Published: msgTime.Format(time.RFC3339Nano),
+			Headers:   mapHeaders(msg.Headers()),
+		})
+	}
+
+	if msgs.Error() != nil {
+		// Ignore timeout errors — they just mean no more messages
+		if msgs.Error().Error() != "nats: timeout" {
+			return nil, msgs.Error()
+		}
+	}
+
+	// Trim to last N messages (we may have fetched more to handle sparse sequences)
+	if len(result) > last {
+		result = result[len(result)-last:]
+	}
+
+	return result, nil
+}
+
+// StreamMessagesConnection is the resolver for the streamMessagesConnection field.
+func (r *queryResolver) StreamMessagesConnection(ctx context.Context, stream string, first *int, after *string, last *int, before *string, subject *string) (*model.StreamMessageConnection, error) {
+	if first != nil && before != nil {
+		return nil, fmt.Errorf("first cannot be combined with before")
+	}
+	if last != nil && after != nil {
+		return nil, fmt.Errorf("last cannot be combined with after")
+	}
+	if first != nil && last != nil {
+		return nil, fmt.Errorf("first cannot be combined with last")
+	}
+
+	backward := last != nil || before != nil
+	limit := 10
+	if first != nil {
+		limit = *first
+	} else if last != nil {
+		limit = *last
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("page size must be > 0")
+	}
+	if limit > maxPageSize {
+		return nil, fmt.Errorf("page size exceeds maximum of %d messages", maxPageSize)
+	}
+
+	var cursorSeq uint64
+	if after != nil || before != nil {
+		c := after
+		if before != nil {
+			c = before
+		}
+		seq, err := decodeCursor(*c)
+		if err != nil {
+			return nil, err
+		}
+		cursorSeq = seq
+	}
+
+	s, err := r.JS.Stream(ctx, stream)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := s.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	firstSeq, lastSeq := info.State.FirstSeq, info.State.LastSeq
+	if info.State.Msgs == 0 {
+		return buildConnection(nil, false, false), nil
+	}
+
+	p := &pageReader{stream: s}
+	if subject != nil {
+		p.subject = *subject
+	}
+
+	if backward {
+		end := lastSeq
+		if before != nil {
+			if cursorSeq <= firstSeq {
+				return buildConnection(nil, true, false), nil
+			}
+			end = min(cursorSeq-1, lastSeq)
+		}
+		return p.backward(ctx, firstSeq, lastSeq, end, limit, before != nil)
+	}
+
+	start := firstSeq
+	if after != nil {
+		if cursorSeq >= lastSeq {
+			return buildConnection(nil, false, true), nil
+		}
+		start = max(cursorSeq+1, firstSeq)
+	}
+	return p.forward(ctx, firstSeq, start, limit, after != nil)
+}
+
+// StreamMessage is the resolver for the streamMessage field.
+func (r *queryResolver) StreamMessage(ctx context.Context, stream string, seq int) (*model.StreamMessage, error) {
+	if seq < 1 {
+		return nil, fmt.Errorf("seq must be >= 1")
+	}
+
+	s, err := r.JS.Stream(ctx, stream)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := s.GetMsg(ctx, uint64(seq))
+	if err != nil {
+		if errors.Is(err, jetstream.ErrMsgNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return mapRawStreamMsg(msg), nil
+}
+
+// StreamLastMessage is the resolver for the streamLastMessage field.
+func (r *queryResolver) StreamLastMessage(ctx context.Context, stream string, subject string) (*model.StreamMessage, error) {
+	s, err := r.JS.Stream(ctx, stream)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := s.GetLastMsgForSubject(ctx, subject)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrMsgNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return mapRawStreamMsg(msg), nil
+}
+
+// Consumers is the resolver for the consumers field.
+func (r *queryResolver) Consumers(ctx context.Context, stream string) ([]*model.ConsumerInfo, error) {
+	s, err := r.JS.Stream(ctx, stream)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*model.ConsumerInfo
+
+	lister := s.ListConsumers(ctx)
+	for ci := range lister.Info() {
+		result = append(result, mapConsumerInfo(ci))
+	}
+
+	if err := lister.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ConsumerInfo is the resolver for the consumerInfo field.
+func (r *queryResolver) ConsumerInfo(ctx context.Context, stream string, name string) (*model.ConsumerInfo, error) {
+	cons, err := r.JS.Consumer(ctx, stream, name)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrConsumerNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	ci, err := cons.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapConsumerInfo(ci), nil
+}
+
+// ObjectStores is the resolver for the objectStores field.
+func (r *queryResolver) ObjectStores(ctx context.Context) ([]*model.ObjectStore, error) {
+	result := []*model.ObjectStore{}
+
+	stores := r.JS.ObjectStores(ctx)
+	for status := range stores.Status() {
+		result = append(result, mapObjectStore(status))
+	}
+
+	if err := stores.Error(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Objects is the resolver for the objects field.
+func (r *queryResolver) Objects(ctx context.Context, bucket string) ([]*model.ObjectInfo, error) {
+	obs, err := r.JS.ObjectStore(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	infos, err := obs.List(ctx)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrNoObjectsFound) {
+			return []*model.ObjectInfo{}, nil
+		}
+		return nil, err
+	}
+
+	result := make([]*model.ObjectInfo, 0, len(infos))
+	for _, info := range infos {
+		result = append(result, mapObjectInfo(info))
+	}
+	return result, nil
+}
+
+// ObjectInfo is the resolver for the objectInfo field.
+func (r *queryResolver) ObjectInfo(ctx context.Context, bucket string, name string) (*model.ObjectInfo, error) {
+	obs, err := r.JS.ObjectStore(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := obs.GetInfo(ctx, name)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrObjectNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return mapObjectInfo(info), nil
+}
+
+// ScheduledMessages is the resolver for the scheduledMessages field.
+func (r *queryResolver) ScheduledMessages(ctx context.Context) ([]*model.ScheduledMessage, error) {
+	sched, err := r.requireScheduler()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create consumer: %w", err)
+		return nil, err
 	}
 
-	// Fetch messages — read up to maxMessages to handle sparse sequences
-	fetchCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
+	msgs, err := sched.List(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	fetchCount := last
-	if startSeq == nil && startTime == nil && info.State.Msgs <= maxMessages {
-		// Read all messages so we can trim to the actual last N
-		fetchCount = int(info.State.Msgs)
+	result := []*model.ScheduledMessage{}
+	for _, msg := range msgs {
+		if !msg.Recurring() {
+			result = append(result, mapScheduledMessage(msg))
+		}
 	}
+	return result, nil
+}
 
-	msgs, err := cons.Fetch(fetchCount, jetstream.FetchMaxWait(3*time.Second))
+// RecurringMessages is the resolver for the recurringMessages field.
+func (r *queryResolver) RecurringMessages(ctx context.Context) ([]*model.RecurringMessage, error) {
+	sched, err := r.requireScheduler()
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch messages: %w", err)
+		return nil, err
 	}
 
-	var result []*model.StreamMessage
-loop:
-	for msg := range msgs.Messages() {
-		select {
-		case <-fetchCtx.Done():
-			break loop
-		default:
+	msgs, err := sched.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*model.RecurringMessage{}
+	for _, msg := range msgs {
+		if msg.Recurring() {
+			result = append(result, mapRecurringMessage(msg))
 		}
+	}
+	return result, nil
+}
 
-		meta, err := msg.Metadata()
-		if err != nil {
+// Services is the resolver for the services field.
+func (r *queryResolver) Services(ctx context.Context, name *string, id *string, timeout *int) ([]*model.Service, error) {
+	replies, err := r.serviceDiscovery(ctx, micro.InfoVerb, name, id, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Service, 0, len(replies))
+	for _, msg := range replies {
+		var info micro.Info
+		if err := json.Unmarshal(msg.Data, &info); err != nil || info.Type != micro.InfoResponseType {
 			continue
 		}
+		result = append(result, mapService(&info))
+	}
 
-		msgTime := meta.Timestamp
-
-		// Apply endTime filter
-		if hasEndTime && msgTime.After(endTimeVal) {
-			break
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
 		}
+		return result[i].ID < result[j].ID
+	})
 
-		result = append(result, &model.StreamMessage{
-			Sequence:  int(meta.Sequence.Stream),
-			Subject:   msg.Subject(),
-			Data:      string(msg.Data()),
-			Published: msgTime.Format(time.RFC3339Nano),
-			Headers:   mapHeaders(msg.Headers()),
+	return result, nil
+}
+
+// ServicePing is the resolver for the servicePing field.
+func (r *queryResolver) ServicePing(ctx context.Context, name *string, id *string, timeout *int) ([]*model.ServicePing, error) {
+	replies, err := r.serviceDiscovery(ctx, micro.PingVerb, name, id, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.ServicePing, 0, len(replies))
+	for _, msg := range replies {
+		var ping micro.Ping
+		if err := json.Unmarshal(msg.Data, &ping); err != nil || ping.Type != micro.PingResponseType {
+			continue
+		}
+		result = append(result, &model.ServicePing{
+			Name:     ping.Name,
+			ID:       ping.ID,
+			Version:  ping.Version,
+			Metadata: mapMetadata(ping.Metadata),
 		})
 	}
 
-	if msgs.Error() != nil {
-		// Ignore timeout errors — they just mean no more messages
-		if msgs.Error().Error() != "nats: timeout" {
-			return nil, msgs.Error()
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
 		}
+		return result[i].ID < result[j].ID
+	})
+
+	return result, nil
+}
+
+// ServiceStats is the resolver for the serviceStats field.
+func (r *queryResolver) ServiceStats(ctx context.Context, name *string, id *string, timeout *int) ([]*model.ServiceStats, error) {
+	replies, err := r.serviceDiscovery(ctx, micro.StatsVerb, name, id, timeout)
+	if err != nil {
+		return nil, err
 	}
 
-	// Trim to last N messages (we may have fetched more to handle sparse sequences)
-	if len(result) > last {
-		result = result[len(result)-last:]
+	result := make([]*model.ServiceStats, 0, len(replies))
+	for _, msg := range replies {
+		var stats micro.Stats
+		if err := json.Unmarshal(msg.Data, &stats); err != nil || stats.Type != micro.StatsResponseType {
+			continue
+		}
+		result = append(result, mapServiceStats(&stats))
 	}
 
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].ID < result[j].ID
+	})
+
 	return result, nil
 }
 
-// Consumers is the resolver for the consumers field.
-func (r *queryResolver) Consumers(ctx context.Context, stream string) ([]*model.ConsumerInfo, error) {
-	s, err := r.JS.Stream(ctx, stream)
+// ServerInfo is the resolver for the serverInfo field.
+func (r *queryResolver) ServerInfo(ctx context.Context, serverID *string, timeout *int) ([]*model.ServerInfo, error) {
+	replies, err := r.sysRequest(ctx, "VARZ", serverID, nil, timeout)
 	if err != nil {
 		return nil, err
 	}
 
-	var result []*model.ConsumerInfo
+	result := make([]*model.ServerInfo, 0, len(replies))
+	for _, resp := range replies {
+		var v varz
+		if resp.Error != nil || json.Unmarshal(resp.Data, &v) != nil {
+			continue
+		}
+		result = append(result, mapServerInfo(resp.Server, &v))
+	}
+	return result, nil
+}
 
-	lister := s.ListConsumers(ctx)
-	for ci := range lister.Info() {
-		result = append(result, mapConsumerInfo(ci))
+// Connections is the resolver for the connections field.
+func (r *queryResolver) Connections(ctx context.Context, serverID *string, account *string, sortBy *model.ConnectionSort, limit *int, timeout *int) ([]*model.ServerConnections, error) {
+	const maxLimit = 1024
+	n := 100
+	if limit != nil {
+		n = *limit
+	}
+	if n <= 0 || n > maxLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxLimit)
 	}
 
-	if err := lister.Err(); err != nil {
+	opts := connzOptions{
+		Sort:  connectionSorts[model.ConnectionSortCid],
+		Auth:  true,
+		Limit: n,
+	}
+	if sortBy != nil {
+		opts.Sort = connectionSorts[*sortBy]
+	}
+	if account != nil {
+		opts.Account = *account
+	}
+
+	replies, err := r.sysRequest(ctx, "CONNZ", serverID, opts, timeout)
+	if err != nil {
 		return nil, err
 	}
 
+	result := make([]*model.ServerConnections, 0, len(replies))
+	for _, resp := range replies {
+		var c connz
+		if resp.Error != nil || json.Unmarshal(resp.Data, &c) != nil {
+			continue
+		}
+		result = append(result, mapServerConnections(resp.Server, &c))
+	}
 	return result, nil
 }
 
-// ConsumerInfo is the resolver for the consumerInfo field.
-func (r *queryResolver) ConsumerInfo(ctx context.Context, stream string, name string) (*model.ConsumerInfo, error) {
-	cons, err := r.JS.Consumer(ctx, stream, name)
+// JetstreamReport is the resolver for the jetstreamReport field.
+func (r *queryResolver) JetstreamReport(ctx context.Context, serverID *string, timeout *int) ([]*model.JetStreamServerReport, error) {
+	replies, err := r.sysRequest(ctx, "JSZ", serverID, nil, timeout)
 	if err != nil {
-		if errors.Is(err, jetstream.ErrConsumerNotFound) {
-			return nil, nil
+		return nil, err
+	}
+
+	result := make([]*model.JetStreamServerReport, 0, len(replies))
+	for _, resp := range replies {
+		var j jsz
+		if resp.Error != nil || json.Unmarshal(resp.Data, &j) != nil {
+			continue
 		}
+		result = append(result, mapJetStreamReport(resp.Server, &j))
+	}
+	return result, nil
+}
+
+// ClusterHealth is the resolver for the clusterHealth field.
+func (r *queryResolver) ClusterHealth(ctx context.Context, serverID *string, timeout *int) ([]*model.ServerHealth, error) {
+	replies, err := r.sysRequest(ctx, "HEALTHZ", serverID, nil, timeout)
+	if err != nil {
 		return nil, err
 	}
 
-	ci, err := cons.Info(ctx)
+	result := make([]*model.ServerHealth, 0, len(replies))
+	for _, resp := range replies {
+		result = append(result, mapServerHealth(resp))
+	}
+	return result, nil
+}
+
+// Data is the resolver for the data field.
+func (r *recurringMessageResolver) Data(ctx context.Context, obj *model.RecurringMessage, encoding *model.Encoding) (string, error) {
+	return encodePayload([]byte(obj.Data), encoding), nil
+}
+
+// Data is the resolver for the data field.
+func (r *scheduledMessageResolver) Data(ctx context.Context, obj *model.ScheduledMessage, encoding *model.Encoding) (string, error) {
+	return encodePayload([]byte(obj.Data), encoding), nil
+}
+
+// SubjectCounts is the resolver for the subjectCounts field.
+func (r *streamInfoResolver) SubjectCounts(ctx context.Context, obj *model.StreamInfo, filter *string) ([]*model.SubjectCount, error) {
+	f := ">"
+	if filter != nil && *filter != "" {
+		f = *filter
+	}
+
+	counts, err := r.streamSubjects(ctx, obj.Name, f)
 	if err != nil {
 		return nil, err
 	}
 
-	return mapConsumerInfo(ci), nil
+	// Sort subjects for deterministic output
+	subjects := make([]string, 0, len(counts))
+	for subj := range counts {
+		subjects = append(subjects, subj)
+	}
+	sort.Strings(subjects)
+
+	result := make([]*model.SubjectCount, len(subjects))
+	for i, subj := range subjects {
+		result[i] = &model.SubjectCount{
+			Subject:  subj,
+			Messages: int(counts[subj]),
+		}
+	}
+
+	return result, nil
+}
+
+// Data is the resolver for the data field.
+func (r *streamMessageResolver) Data(ctx context.Context, obj *model.StreamMessage, encoding *model.Encoding) (string, error) {
+	return encodePayload([]byte(obj.Data), encoding), nil
 }
 
 // StreamSubscribe is the resolver for the streamSubscribe field.
-func (r *subscriptionResolver) StreamSubscribe(ctx context.Context, stream string, subject *string) (<-chan *model.StreamMessage, error) {
+func (r *subscriptionResolver) StreamSubscribe(ctx context.Context, stream string, subject *string, startSeq *int, startTime *string, deliverLastPerSubject *bool) (<-chan *model.StreamMessage, error) {
 	s, err := r.JS.Stream(ctx, stream)
 	if err != nil {
 		return nil, err
 	}
 
-	// Build ordered consumer config — deliver only new messages
+	// Build ordered consumer config — deliver only new messages unless a start position is given
 	cfg := jetstream.OrderedConsumerConfig{
 		DeliverPolicy: jetstream.DeliverNewPolicy,
 	}
@@ -886,6 +1915,35 @@ func (r *subscriptionResolver) StreamSubscribe(ctx context.Context, stream strin
 		cfg.FilterSubjects = []string{*subject}
 	}
 
+	lastPerSubject := deliverLastPerSubject != nil && *deliverLastPerSubject
+	positions := 0
+	for _, set := range []bool{startSeq != nil, startTime != nil, lastPerSubject} {
+		if set {
+			positions++
+		}
+	}
+	if positions > 1 {
+		return nil, fmt.Errorf("only one of startSeq, startTime and deliverLastPerSubject can be set")
+	}
+
+	switch {
+	case startSeq != nil:
+		if *startSeq < 1 {
+			return nil, fmt.Errorf("startSeq must be >= 1")
+		}
+		cfg.DeliverPolicy = jetstream.DeliverByStartSequencePolicy
+		cfg.OptStartSeq = uint64(*startSeq)
+	case startTime != nil:
+		t, err := parseRFC3339(*startTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid startTime format (expected RFC3339): %w", err)
+		}
+		cfg.DeliverPolicy = jetstream.DeliverByStartTimePolicy
+		cfg.OptStartTime = &t
+	case lastPerSubject:
+		cfg.DeliverPolicy = jetstream.DeliverLastPerSubjectPolicy
+	}
+
 	cons, err := s.OrderedConsumer(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create consumer: %w", err)
@@ -903,6 +1961,9 @@ func (r *subscriptionResolver) StreamSubscribe(ctx context.Context, stream strin
 		defer close(ch)
 		defer iter.Stop()
 
+		// The ordered consumer recreates itself under a new name after a reset
+		var consumerName string
+
 		for {
 			select {
 			case <-ctx.Done():
@@ -921,12 +1982,14 @@ func (r *subscriptionResolver) StreamSubscribe(ctx context.Context, stream strin
 			}
 
 			sm := &model.StreamMessage{
-				Sequence:  int(meta.Sequence.Stream),
-				Subject:   msg.Subject(),
-				Data:      string(msg.Data()),
-				Published: meta.Timestamp.Format(time.RFC3339Nano),
-				Headers:   mapHeaders(msg.Headers()),
+				Sequence:      int(meta.Sequence.Stream),
+				Subject:       msg.Subject(),
+				Data:          string(msg.Data()),
+				Published:     meta.Timestamp.Format(time.RFC3339Nano),
+				Headers:       mapHeaders(msg.Headers()),
+				ConsumerReset: consumerName != "" && meta.Consumer != consumerName,
 			}
+			consumerName = meta.Consumer
 
 			select {
 			case ch <- sm:
@@ -939,15 +2002,199 @@ func (r *subscriptionResolver) StreamSubscribe(ctx context.Context, stream strin
 	return ch, nil
 }
 
+// SubjectSubscribe is the resolver for the subjectSubscribe field.
+func (r *subscriptionResolver) SubjectSubscribe(ctx context.Context, subject string, queueGroup *string) (<-chan *model.CoreMessage, error) {
+	// The NATS client drops messages once this buffer is full (slow consumer)
+	msgs := make(chan *nats.Msg, 256)
+
+	var sub *nats.Subscription
+	var err error
+	if queueGroup != nil && *queueGroup != "" {
+		sub, err = r.NC.ChanQueueSubscribe(subject, *queueGroup, msgs)
+	} else {
+		sub, err = r.NC.ChanSubscribe(subject, msgs)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	ch := make(chan *model.CoreMessage, 1)
+
+	go func() {
+		defer close(ch)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-msgs:
+				select {
+				case ch <- mapCoreMessage(msg):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// KvWatch is the resolver for the kvWatch field.
+func (r *subscriptionResolver) KvWatch(ctx context.Context, bucket string, keys []string, includeHistory *bool, ignoreDeletes *bool) (<-chan *model.KVEntry, error) {
+	kv, err := r.JS.KeyValue(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []jetstream.WatchOpt
+	if includeHistory != nil && *includeHistory {
+		opts = append(opts, jetstream.IncludeHistory())
+	}
+	if ignoreDeletes != nil && *ignoreDeletes {
+		opts = append(opts, jetstream.IgnoreDeletes())
+	}
+
+	var watcher jetstream.KeyWatcher
+	switch len(keys) {
+	case 0:
+		watcher, err = kv.WatchAll(ctx, opts...)
+	case 1:
+		watcher, err = kv.Watch(ctx, keys[0], opts...)
+	default:
+		watcher, err = kv.WatchFiltered(ctx, keys, opts...)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to start watcher: %w", err)
+	}
+
+	ch := make(chan *model.KVEntry, 1)
+
+	go func() {
+		defer close(ch)
+		defer watcher.Stop()
+
+		for {
+			var entry jetstream.KeyValueEntry
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok := <-watcher.Updates():
+				if !ok {
+					return
+				}
+				entry = e
+			}
+
+			// A nil entry marks the end of initial values — nothing to send
+			if entry == nil {
+				continue
+			}
+
+			select {
+			case ch <- mapKVEntry(entry):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// JetstreamEvents is the resolver for the jetstreamEvents field.
+func (r *subscriptionResolver) JetstreamEvents(ctx context.Context, types []model.JetStreamEventType, stream *string, consumer *string) (<-chan model.JetStreamEvent, error) {
+	var streamName, consumerName string
+	if stream != nil {
+		streamName = *stream
+	}
+	if consumer != nil {
+		consumerName = *consumer
+	}
+
+	sources := jsEventSourcesFor(types, consumerName)
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no consumer event types selected, stream events cannot be filtered by consumer")
+	}
+
+	// All subscriptions share one channel; the NATS client drops messages once it is full
+	msgs := make(chan *nats.Msg, 256)
+	subs := make([]*nats.Subscription, 0, len(sources))
+	unsubscribe := func() {
+		for _, sub := range subs {
+			sub.Unsubscribe()
+		}
+	}
+	for _, src := range sources {
+		sub, err := r.NC.ChanSubscribe(src.subject(streamName, consumerName), msgs)
+		if err != nil {
+			unsubscribe()
+			return nil, fmt.Errorf("failed to subscribe: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+
+	ch := make(chan model.JetStreamEvent, 1)
+
+	go func() {
+		defer close(ch)
+		defer unsubscribe()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-msgs:
+				event := mapJetStreamEvent(msg)
+				if event == nil {
+					continue
+				}
+				select {
+				case ch <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// CoreMessage returns CoreMessageResolver implementation.
+func (r *Resolver) CoreMessage() CoreMessageResolver { return &coreMessageResolver{r} }
+
+// KVEntry returns KVEntryResolver implementation.
+func (r *Resolver) KVEntry() KVEntryResolver { return &kVEntryResolver{r} }
+
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
+// RecurringMessage returns RecurringMessageResolver implementation.
+func (r *Resolver) RecurringMessage() RecurringMessageResolver { return &recurringMessageResolver{r} }
+
+// ScheduledMessage returns ScheduledMessageResolver implementation.
+func (r *Resolver) ScheduledMessage() ScheduledMessageResolver { return &scheduledMessageResolver{r} }
+
+// StreamInfo returns StreamInfoResolver implementation.
+func (r *Resolver) StreamInfo() StreamInfoResolver { return &streamInfoResolver{r} }
+
+// StreamMessage returns StreamMessageResolver implementation.
+func (r *Resolver) StreamMessage() StreamMessageResolver { return &streamMessageResolver{r} }
+
 // Subscription returns SubscriptionResolver implementation.
 func (r *Resolver) Subscription() SubscriptionResolver { return &subscriptionResolver{r} }
 
+type coreMessageResolver struct{ *Resolver }
+type kVEntryResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type recurringMessageResolver struct{ *Resolver }
+type scheduledMessageResolver struct{ *Resolver }
+type streamInfoResolver struct{ *Resolver }
+type streamMessageResolver struct{ *Resolver }
 type subscriptionResolver struct{ *Resolver }
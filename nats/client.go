@@ -13,7 +13,12 @@ func Connect() (*nats.Conn, jetstream.JetStream, error) {
 		url = nats.DefaultURL
 	}
 
-	nc, err := nats.Connect(url)
+	var opts []nats.Option
+	if creds := os.Getenv("NATS_CREDS"); creds != "" {
+		opts = append(opts, nats.UserCredentials(creds))
+	}
+
+	nc, err := nats.Connect(url, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
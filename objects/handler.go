@@ -0,0 +1,136 @@
+package objects
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// Handler serves Object Store data over plain HTTP, since GraphQL strings are
+// a poor transport for multi-megabyte files. Both directions are streamed
+// chunk by chunk, so objects are never held in memory as a whole.
+//
+//	GET /objects/{bucket}/{name...}  download an object
+//	PUT /objects/{bucket}/{name...}  upload the request body (replaces an existing object)
+func Handler(js jetstream.JetStream) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /objects/{bucket}/{name...}", func(w http.ResponseWriter, r *http.Request) {
+		download(js, w, r)
+	})
+	mux.HandleFunc("PUT /objects/{bucket}/{name...}", func(w http.ResponseWriter, r *http.Request) {
+		upload(js, w, r)
+	})
+	return mux
+}
+
+// uploadResult is returned as JSON after a successful upload.
+type uploadResult struct {
+	Bucket string `json:"bucket"`
+	Name   string `json:"name"`
+	Size   uint64 `json:"size"`
+	Chunks uint32 `json:"chunks"`
+	Digest string `json:"digest"`
+}
+
+func download(js jetstream.JetStream, w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	obs, err := js.ObjectStore(ctx, r.PathValue("bucket"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// Links to other objects are followed by Get
+	res, err := obs.Get(ctx, r.PathValue("name"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer res.Close()
+
+	info, err := res.Info()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	contentType := info.Headers.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", contentType)
+	// Content-Type is chosen by whoever uploaded the object, so never let a browser
+	// render it inline on this origin (e.g. text/html with scripts)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(info.Name)})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+	w.Header().Set("Content-Disposition", disposition)
+	w.Header().Set("Content-Length", strconv.FormatUint(info.Size, 10))
+	if info.Digest != "" {
+		w.Header().Set("ETag", strconv.Quote(info.Digest))
+	}
+
+	// Headers are already sent, so a failure midway can only cut the response short
+	io.Copy(w, res)
+}
+
+func upload(js jetstream.JetStream, w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	obs, err := js.ObjectStore(ctx, r.PathValue("bucket"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	meta := jetstream.ObjectMeta{
+		Name:        r.PathValue("name"),
+		Description: r.URL.Query().Get("description"),
+	}
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		meta.Headers = nats.Header{"Content-Type": []string{ct}}
+	}
+
+	info, err := obs.Put(ctx, meta, r.Body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(uploadResult{
+		Bucket: info.Bucket,
+		Name:   info.Name,
+		Size:   info.Size,
+		Chunks: info.Chunks,
+		Digest: info.Digest,
+	})
+}
+
+// writeError writes err in the same JSON shape as GraphQL errors.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, jetstream.ErrBucketNotFound), errors.Is(err, jetstream.ErrObjectNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, jetstream.ErrBadObjectMeta), errors.Is(err, jetstream.ErrInvalidStoreName):
+		status = http.StatusBadRequest
+	}
+
+	body, _ := json.Marshal(map[string]any{
+		"errors": []map[string]string{{"message": err.Error()}},
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
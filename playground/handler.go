@@ -39,6 +39,30 @@ const exampleQuery = `# List all Key-Value stores
 #   }
 # }
 
+# -----------------------------------------------
+# Get all retained revisions of a key (oldest first)
+# Includes delete/purge markers; limit keeps the N most recent
+#
+# {
+#   kvHistory(bucket: "my-bucket", key: "my-key", limit: 10) {
+#     value
+#     revision
+#     created
+#     operation
+#   }
+# }
+
+# -----------------------------------------------
+# Get a specific past revision of a key
+#
+# {
+#   kvGet(bucket: "my-bucket", key: "my-key", revision: 3) {
+#     value
+#     revision
+#     created
+#   }
+# }
+
 # -----------------------------------------------
 # Create a new KV bucket (mutation)
 #
@@ -63,6 +87,41 @@ const exampleQuery = `# List all Key-Value stores
 #   }
 # }
 
+# -----------------------------------------------
+# Create a key only if it does not exist (mutation)
+# Fails with extensions.code = "KEY_EXISTS" otherwise
+#
+# mutation {
+#   kvCreateKey(bucket: "my-bucket", key: "my-key", value: "hello") {
+#     key
+#     revision
+#   }
+# }
+
+# -----------------------------------------------
+# Update a key only if its revision matches (mutation)
+# Fails with extensions.code = "REVISION_MISMATCH" otherwise
+#
+# mutation {
+#   kvUpdateKey(bucket: "my-bucket", key: "my-key", value: "world", revision: 1) {
+#     key
+#     value
+#     revision
+#   }
+# }
+
+# -----------------------------------------------
+# Roll a key back to a previous revision (mutation)
+# The old value is stored again as a new revision
+#
+# mutation {
+#   kvRevert(bucket: "my-bucket", key: "my-key", revision: 3) {
+#     key
+#     value
+#     revision
+#   }
+# }
+
 # -----------------------------------------------
 # Delete a key (leaves tombstone, key appears deleted)
 #
@@ -105,7 +164,7 @@ const exampleQuery = `# List all Key-Value stores
 
 # -----------------------------------------------
 # Purge all messages from a stream (mutation)
-# Stream itself is preserved, only messages are removed
+# Stream itself is preserved, returns the number of purged messages
 #
 # mutation {
 #   streamPurge(name: "my-stream")
@@ -118,6 +177,29 @@ const exampleQuery = `# List all Key-Value stores
 #   streamPurge(name: "my-stream", subject: "orders.error")
 # }
 
+# -----------------------------------------------
+# Keep only the newest N messages per subject (mutation)
+# Returns the number of purged messages
+#
+# mutation {
+#   streamPurge(name: "my-stream", subject: "orders.>", keep: 100)
+# }
+
+# -----------------------------------------------
+# Purge all messages below a sequence (mutation)
+#
+# mutation {
+#   streamPurge(name: "my-stream", upToSeq: 5000)
+# }
+
+# -----------------------------------------------
+# Delete a single message by sequence (mutation)
+# erase: true overwrites the data on disk (secure delete)
+#
+# mutation {
+#   streamDeleteMessage(stream: "my-stream", seq: 42, erase: true)
+# }
+
 # -----------------------------------------------
 # Update stream settings (mutation)
 # Only provided fields will be changed
@@ -186,6 +268,114 @@ const exampleQuery = `# List all Key-Value stores
 #   }
 # }
 
+# -----------------------------------------------
+# Page through messages with cursors
+# Pass pageInfo.endCursor as "after" for the next page,
+# or use last/before to page backward from the end
+#
+# {
+#   streamMessagesConnection(stream: "my-stream", first: 20, after: "c2VxOjQy") {
+#     edges {
+#       cursor
+#       node { sequence subject data }
+#     }
+#     pageInfo {
+#       hasNextPage
+#       hasPreviousPage
+#       endCursor
+#     }
+#   }
+# }
+
+# -----------------------------------------------
+# Binary payloads: read data as base64 or hex
+# (also available on KVEntry.value)
+#
+# {
+#   streamMessages(stream: "my-stream", last: 5) {
+#     sequence
+#     data(encoding: BASE64)
+#   }
+# }
+
+# -----------------------------------------------
+# Get a single message by sequence number (direct get)
+# Returns null if the message does not exist
+#
+# {
+#   streamMessage(stream: "my-stream", seq: 42) {
+#     sequence
+#     subject
+#     data
+#     published
+#   }
+# }
+
+# -----------------------------------------------
+# Get the latest message on a subject (direct get)
+#
+# {
+#   streamLastMessage(stream: "my-stream", subject: "orders.new") {
+#     sequence
+#     data
+#     published
+#   }
+# }
+
+# -----------------------------------------------
+# Health and stats of every NATS server
+# Requires system account credentials (NATS_CREDS)
+#
+# {
+#   clusterHealth {
+#     serverName
+#     healthy
+#     error
+#   }
+#   serverInfo {
+#     serverName
+#     version
+#     uptime
+#     connections
+#     slowConsumers
+#   }
+# }
+
+# -----------------------------------------------
+# Busiest client connections per server
+#
+# {
+#   connections(sortBy: BYTES_TO, limit: 10) {
+#     serverName
+#     total
+#     connections {
+#       name
+#       ip
+#       account
+#       outBytes
+#     }
+#   }
+# }
+
+# -----------------------------------------------
+# JetStream usage per server and meta cluster state
+#
+# {
+#   jetstreamReport {
+#     serverName
+#     streams
+#     storage
+#     metaCluster {
+#       leader
+#       replicas {
+#         name
+#         current
+#         lag
+#       }
+#     }
+#   }
+# }
+
 # -----------------------------------------------
 # Subscribe to new messages in real-time (WebSocket)
 #
@@ -202,6 +392,64 @@ const exampleQuery = `# List all Key-Value stores
 #   }
 # }
 
+# -----------------------------------------------
+# Resume a subscription after a reconnect (WebSocket)
+# startSeq = last sequence seen + 1; or use startTime / deliverLastPerSubject
+#
+# subscription {
+#   streamSubscribe(stream: "my-stream", startSeq: 1043) {
+#     sequence
+#     data
+#     consumerReset
+#   }
+# }
+
+# -----------------------------------------------
+# Watch KV bucket changes in real-time (WebSocket)
+# Current values are sent first, then live updates
+#
+# subscription {
+#   kvWatch(bucket: "my-bucket", keys: ["config.*"]) {
+#     key
+#     value
+#     revision
+#     operation
+#   }
+# }
+
+# -----------------------------------------------
+# Subscribe to a core NATS subject (WebSocket)
+# No stream needed, only live messages are delivered
+#
+# subscription {
+#   subjectSubscribe(subject: "telemetry.>") {
+#     subject
+#     reply
+#     data
+#   }
+# }
+
+# -----------------------------------------------
+# Watch JetStream advisories in real-time (WebSocket)
+# Poison messages and consumer stalls as they happen
+#
+# subscription {
+#   jetstreamEvents(types: [MAX_DELIVERIES, MESSAGE_TERMINATED]) {
+#     type
+#     timestamp
+#     stream
+#     ... on MaxDeliveriesEvent {
+#       consumer
+#       streamSeq
+#       deliveries
+#     }
+#     ... on MessageTerminatedEvent {
+#       consumer
+#       reason
+#     }
+#   }
+# }
+
 # -----------------------------------------------
 # Publish a message to a subject (mutation)
 #
@@ -227,14 +475,144 @@ const exampleQuery = `# List all Key-Value stores
 #   }
 # }
 
+# -----------------------------------------------
+# Publish a binary payload (mutation)
+# data is decoded from base64 (or hex) before publishing
+#
+# mutation {
+#   publish(subject: "orders.new", data: "CAESBWhlbGxv", encoding: BASE64) {
+#     stream
+#     sequence
+#   }
+# }
+
+# -----------------------------------------------
+# Publish over core NATS without a stream (mutation)
+#
+# mutation {
+#   corePublish(subject: "telemetry.cpu", data: "42")
+# }
+
+# -----------------------------------------------
+# Send a request and wait for the reply (mutation)
+# Core NATS request/reply, works without a stream
+#
+# mutation {
+#   request(subject: "users.get", data: "{\"id\": 42}", timeout: 2) {
+#     data
+#   }
+# }
+
+# -----------------------------------------------
+# Discover NATS micro services and their endpoints
+# Waits "timeout" seconds (default 1) for every instance to answer
+#
+# {
+#   services {
+#     name
+#     id
+#     version
+#     endpoints {
+#       name
+#       subject
+#     }
+#   }
+# }
+
+# -----------------------------------------------
+# Request statistics of a service
+#
+# {
+#   serviceStats(name: "users") {
+#     id
+#     endpoints {
+#       name
+#       numRequests
+#       numErrors
+#       averageProcessingTime
+#     }
+#   }
+# }
+
+# -----------------------------------------------
+# Call a service endpoint by name (mutation)
+#
+# mutation {
+#   serviceInvoke(service: "users", endpoint: "get", data: "{\"id\": 42}") {
+#     data
+#   }
+# }
+
 # -----------------------------------------------
 # Publish a message with delay (mutation)
-# The message will be published after 30 seconds
+# The message will be published after 30 seconds, even if the server restarts
 #
 # mutation {
 #   publishScheduled(subject: "orders.new", data: "{\"id\": 1}", delay: 30)
 # }
 
+# -----------------------------------------------
+# List pending scheduled messages
+#
+# {
+#   scheduledMessages {
+#     id
+#     subject
+#     data
+#     fireAt
+#   }
+# }
+
+# -----------------------------------------------
+# Cancel or reschedule a scheduled message (mutation)
+# Use the ID returned by publishScheduled
+#
+# mutation {
+#   scheduledReschedule(id: "<schedule id>", delay: 3600) {
+#     fireAt
+#   }
+# }
+#
+# mutation {
+#   scheduledCancel(id: "<schedule id>")
+# }
+
+# -----------------------------------------------
+# Publish on a cron schedule (mutation)
+# Also: recurringMessages, recurringPause(id), recurringResume(id), recurringDelete(id)
+#
+# mutation {
+#   publishRecurring(subject: "heartbeat.gateway", data: "ping", cron: "*/5 * * * *", timezone: "UTC") {
+#     id
+#     nextRun
+#   }
+# }
+
+# -----------------------------------------------
+# List Object Store buckets and their objects
+# Object data: GET / PUT /objects/{bucket}/{name}
+#
+# {
+#   objectStores {
+#     bucket
+#     size
+#   }
+#   objects(bucket: "files") {
+#     name
+#     size
+#     digest
+#   }
+# }
+
+# -----------------------------------------------
+# Link an object under a second name (mutation)
+#
+# mutation {
+#   objectLink(bucket: "files", name: "latest.pdf", targetBucket: "files", targetName: "reports/2026-02.pdf") {
+#     name
+#   }
+# }
+
 # -----------------------------------------------
 # List all JetStream streams
 # Returns stream config and runtime statistics
@@ -256,6 +634,64 @@ const exampleQuery = `# List all Key-Value stores
 #   }
 # }
 
+# -----------------------------------------------
+# Get a single stream with its full config and state
+# Returns null if the stream does not exist
+#
+# {
+#   stream(name: "my-stream") {
+#     name
+#     description
+#     discard
+#     duplicates
+#     allowRollup
+#     denyDelete
+#     denyPurge
+#     firstSeq
+#     lastSeq
+#     firstTs
+#     lastTs
+#     numDeleted
+#     numSubjects
+#   }
+# }
+
+# -----------------------------------------------
+# Message counts per concrete subject of a stream
+# filter accepts wildcards (default ">" = all subjects)
+#
+# {
+#   stream(name: "my-stream") {
+#     subjectCounts(filter: "orders.>") {
+#       subject
+#       messages
+#     }
+#   }
+# }
+
+# -----------------------------------------------
+# JetStream usage and limits of the account
+# Limits of -1 mean unlimited
+#
+# {
+#   accountInfo {
+#     memory
+#     storage
+#     streams
+#     consumers
+#     limits {
+#       maxMemory
+#       maxStorage
+#       maxStreams
+#       maxConsumers
+#     }
+#     api {
+#       total
+#       errors
+#     }
+#   }
+# }
+
 # -----------------------------------------------
 # List consumers on a stream
 #
@@ -309,6 +745,46 @@ const exampleQuery = `# List all Key-Value stores
 #   }
 # }
 
+# -----------------------------------------------
+# Update a consumer, only provided fields change (mutation)
+#
+# mutation {
+#   consumerUpdate(
+#     stream: "my-stream"
+#     name: "my-consumer"
+#     maxDeliver: 5
+#     backoff: ["1s", "10s", "1m"]
+#   ) {
+#     name
+#     maxDeliver
+#     backoff
+#   }
+# }
+
+# -----------------------------------------------
+# Fetch a batch of messages from a pull consumer (mutation)
+#
+# mutation {
+#   consumerFetch(stream: "my-stream", consumer: "my-consumer", batch: 10, maxWait: 5) {
+#     token
+#     deliveryCount
+#     pending
+#     message {
+#       sequence
+#       subject
+#       data
+#     }
+#   }
+# }
+
+# -----------------------------------------------
+# Acknowledge a fetched message (mutation)
+# Also: messageNak(token, delay), messageTerm(token), messageInProgress(token)
+#
+# mutation {
+#   messageAck(token: "<token from consumerFetch>")
+# }
+
 # -----------------------------------------------
 # Delete a consumer (mutation)
 #
@@ -0,0 +1,541 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/nats-io/nuid"
+	"github.com/robfig/cron/v3"
+)
+
+// Bucket is the KV bucket that holds pending scheduled messages.
+const Bucket = "nats_graphql_schedules"
+
+// retryDelay is how long to wait before retrying a failed publish.
+const retryDelay = 10 * time.Second
+
+// cleanupInterval is how often delete markers left by published and cancelled
+// messages are removed from the bucket.
+const cleanupInterval = time.Hour
+
+// ErrNotFound is returned when a scheduled message does not exist (anymore).
+var ErrNotFound = errors.New("scheduled message not found")
+
+// errMalformed is returned for entries that cannot be decoded. They never
+// become valid, so they are removed instead of being retried.
+var errMalformed = errors.New("malformed scheduled message")
+
+// Message is a scheduled publish, stored as JSON in the bucket under its ID.
+type Message struct {
+	ID      string      `json:"id"`
+	Subject string      `json:"subject"`
+	Data    []byte      `json:"data"`
+	Header  nats.Header `json:"header,omitempty"`
+	FireAt  time.Time   `json:"fire_at"`
+	Created time.Time   `json:"created"`
+
+	// Recurring messages have a cron spec. After each publish they are moved
+	// to the next occurrence instead of being removed.
+	Cron     string `json:"cron,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+	Paused   bool   `json:"paused,omitempty"`
+}
+
+// Recurring reports whether the message is published on a cron schedule.
+func (m *Message) Recurring() bool {
+	return m.Cron != ""
+}
+
+// Scheduler publishes messages at a later time. Pending messages live in a
+// JetStream KV bucket, so they survive restarts and are shared by all replicas.
+//
+// Every replica watches the bucket and arms a timer per message. When a timer
+// fires, the replica publishes with a Nats-Msg-Id derived from the entry
+// revision and then removes the entry. If several replicas fire at once,
+// JetStream deduplication drops the extra copies; if a replica dies before
+// removing the entry, another one (or the restarted one) publishes it again.
+type Scheduler struct {
+	js jetstream.JetStream
+	kv jetstream.KeyValue
+
+	mu     sync.Mutex
+	timers map[string]*time.Timer
+}
+
+// New opens the schedule bucket, creating it if needed.
+func New(ctx context.Context, js jetstream.JetStream) (*Scheduler, error) {
+	kv, err := js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+		Bucket:      Bucket,
+		Description: "Pending scheduled publishes (nats-graphql)",
+		History:     1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open schedule bucket: %w", err)
+	}
+
+	return &Scheduler{
+		js:     js,
+		kv:     kv,
+		timers: make(map[string]*time.Timer),
+	}, nil
+}
+
+// Start re-arms timers for all stored messages and keeps following the bucket
+// until ctx is cancelled. Delete markers are removed periodically, so the
+// bucket does not grow with every published or cancelled message.
+func (s *Scheduler) Start(ctx context.Context) error {
+	w, err := s.kv.WatchAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to watch schedule bucket: %w", err)
+	}
+
+	go s.run(ctx, w)
+	return nil
+}
+
+// run applies bucket changes until ctx is cancelled. If the watcher stops
+// (e.g. its consumer was lost), a new one is created; it replays all entries,
+// so changes missed meanwhile are armed too.
+func (s *Scheduler) run(ctx context.Context, w jetstream.KeyWatcher) {
+	cleanup := time.NewTicker(cleanupInterval)
+	defer cleanup.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			w.Stop()
+			s.stopAll()
+			return
+		case <-cleanup.C:
+			s.purgeDeletes(ctx)
+		case entry, ok := <-w.Updates():
+			if !ok {
+				if w = s.rewatch(ctx); w == nil {
+					s.stopAll()
+					return
+				}
+				continue
+			}
+			// nil marks the end of the initial values
+			if entry == nil {
+				continue
+			}
+			s.apply(entry)
+		}
+	}
+}
+
+// rewatch creates a new watcher, retrying until it succeeds. It returns nil
+// once ctx is cancelled.
+func (s *Scheduler) rewatch(ctx context.Context) jetstream.KeyWatcher {
+	if ctx.Err() != nil {
+		return nil
+	}
+	log.Printf("scheduler: bucket watcher stopped, restarting")
+	for {
+		w, err := s.kv.WatchAll(ctx)
+		if err == nil {
+			return w
+		}
+		log.Printf("scheduler: failed to watch schedule bucket, retrying in %s: %v", retryDelay, err)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+// Schedule stores a message to be published to subject at fireAt.
+func (s *Scheduler) Schedule(ctx context.Context, subject string, data []byte, header nats.Header, fireAt time.Time) (*Message, error) {
+	msg := &Message{
+		ID:      nuid.Next(),
+		Subject: subject,
+		Data:    data,
+		Header:  header,
+		FireAt:  fireAt.UTC(),
+		Created: time.Now().UTC(),
+	}
+
+	value, err := s.encode(msg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.kv.Create(ctx, msg.ID, value); err != nil {
+		return nil, fmt.Errorf("failed to store scheduled message: %w", err)
+	}
+
+	return msg, nil
+}
+
+// ScheduleRecurring stores a message to be published to subject on every
+// occurrence of the cron spec, evaluated in the given IANA timezone.
+func (s *Scheduler) ScheduleRecurring(ctx context.Context, subject string, data []byte, header nats.Header, spec, timezone string) (*Message, error) {
+	msg := &Message{
+		ID:       nuid.Next(),
+		Subject:  subject,
+		Data:     data,
+		Header:   header,
+		Created:  time.Now().UTC(),
+		Cron:     spec,
+		Timezone: timezone,
+	}
+
+	next, err := nextRun(msg, msg.Created)
+	if err != nil {
+		return nil, err
+	}
+	msg.FireAt = next
+
+	value, err := s.encode(msg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.kv.Create(ctx, msg.ID, value); err != nil {
+		return nil, fmt.Errorf("failed to store recurring message: %w", err)
+	}
+
+	return msg, nil
+}
+
+// SetPaused pauses or resumes a recurring message. Resuming continues with
+// the next occurrence from now, missed ones are not published.
+func (s *Scheduler) SetPaused(ctx context.Context, id string, paused bool) (*Message, error) {
+	msg, rev, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !msg.Recurring() {
+		return nil, fmt.Errorf("scheduled message %s is not recurring", id)
+	}
+
+	msg.Paused = paused
+	if !paused {
+		if msg.FireAt, err = nextRun(msg, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	value, err := s.encode(msg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.kv.Update(ctx, id, value, rev); err != nil {
+		if errors.Is(err, jetstream.ErrKeyExists) {
+			return nil, fmt.Errorf("scheduled message %s changed while updating, try again", id)
+		}
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// List returns all pending messages, ordered by fire time.
+func (s *Scheduler) List(ctx context.Context) ([]*Message, error) {
+	lister, err := s.kv.ListKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer lister.Stop()
+
+	result := []*Message{}
+	for key := range lister.Keys() {
+		msg, _, err := s.load(ctx, key)
+		if errors.Is(err, ErrNotFound) {
+			// Published or cancelled while listing
+			continue
+		}
+		if errors.Is(err, errMalformed) {
+			// Removed by the watcher
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, msg)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].FireAt.Before(result[j].FireAt)
+	})
+	return result, nil
+}
+
+// Cancel removes a pending message so it is never published. recurring selects
+// the kind of message to remove, the other kind is reported as ErrNotFound.
+func (s *Scheduler) Cancel(ctx context.Context, id string, recurring bool) error {
+	msg, rev, err := s.load(ctx, id)
+	if err != nil {
+		return err
+	}
+	if msg.Recurring() != recurring {
+		return ErrNotFound
+	}
+
+	if err := s.kv.Purge(ctx, id, jetstream.LastRevision(rev)); err != nil {
+		if errors.Is(err, jetstream.ErrKeyExists) {
+			return fmt.Errorf("scheduled message %s changed while cancelling, try again", id)
+		}
+		return err
+	}
+	return nil
+}
+
+// Reschedule moves a pending message to a new fire time.
+func (s *Scheduler) Reschedule(ctx context.Context, id string, fireAt time.Time) (*Message, error) {
+	msg, rev, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Recurring() {
+		return nil, fmt.Errorf("scheduled message %s is recurring, its time follows the cron expression", id)
+	}
+
+	msg.FireAt = fireAt.UTC()
+	value, err := s.encode(msg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.kv.Update(ctx, id, value, rev); err != nil {
+		if errors.Is(err, jetstream.ErrKeyExists) {
+			return nil, fmt.Errorf("scheduled message %s changed while rescheduling, try again", id)
+		}
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// encode serializes a message for the bucket. The payload is stored base64
+// encoded, so the entry can exceed the server's max payload even when the
+// payload alone does not; such messages are rejected here.
+func (s *Scheduler) encode(msg *Message) ([]byte, error) {
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	if limit := s.js.Conn().MaxPayload(); int64(len(value)) > limit {
+		return nil, fmt.Errorf("scheduled message too large: %d bytes once encoded (max %d)", len(value), limit)
+	}
+	return value, nil
+}
+
+// load reads a pending message and its current revision. The revision is also
+// returned with errMalformed, so the entry can be removed.
+func (s *Scheduler) load(ctx context.Context, id string) (*Message, uint64, error) {
+	entry, err := s.kv.Get(ctx, id)
+	if errors.Is(err, jetstream.ErrKeyNotFound) || errors.Is(err, jetstream.ErrInvalidKey) {
+		return nil, 0, ErrNotFound
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var msg Message
+	if err := json.Unmarshal(entry.Value(), &msg); err != nil {
+		return nil, entry.Revision(), fmt.Errorf("%w %s: %v", errMalformed, id, err)
+	}
+	return &msg, entry.Revision(), nil
+}
+
+// apply arms or disarms the timer for a bucket change.
+func (s *Scheduler) apply(entry jetstream.KeyValueEntry) {
+	key := entry.Key()
+	if entry.Operation() != jetstream.KeyValuePut {
+		s.disarm(key)
+		return
+	}
+
+	var msg Message
+	if err := json.Unmarshal(entry.Value(), &msg); err != nil {
+		s.disarm(key)
+		s.drop(key, entry.Revision(), err)
+		return
+	}
+
+	if msg.Paused {
+		s.disarm(key)
+		return
+	}
+
+	s.arm(key, entry.Revision(), time.Until(msg.FireAt))
+}
+
+// arm (re)starts the timer for key, replacing any previous one.
+func (s *Scheduler) arm(key string, rev uint64, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t, ok := s.timers[key]; ok {
+		t.Stop()
+	}
+	s.timers[key] = time.AfterFunc(d, func() { s.fire(key, rev) })
+}
+
+func (s *Scheduler) disarm(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t, ok := s.timers[key]; ok {
+		t.Stop()
+		delete(s.timers, key)
+	}
+}
+
+func (s *Scheduler) stopAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, t := range s.timers {
+		t.Stop()
+		delete(s.timers, key)
+	}
+}
+
+// drop removes an entry that cannot be decoded, provided it is still at rev.
+func (s *Scheduler) drop(key string, rev uint64, reason error) {
+	log.Printf("scheduler: removing malformed entry %s: %v", key, reason)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// A revision mismatch means it was replaced or another replica removed it
+	if err := s.kv.Purge(ctx, key, jetstream.LastRevision(rev)); err != nil && !errors.Is(err, jetstream.ErrKeyExists) {
+		log.Printf("scheduler: failed to remove malformed entry %s: %v", key, err)
+	}
+}
+
+// fire publishes the message stored under key, provided it is still at rev.
+func (s *Scheduler) fire(key string, rev uint64) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	msg, current, err := s.load(ctx, key)
+	if errors.Is(err, ErrNotFound) {
+		// Already published by another replica, or cancelled
+		return
+	}
+	if errors.Is(err, errMalformed) {
+		s.drop(key, current, err)
+		return
+	}
+	if err != nil {
+		log.Printf("scheduler: failed to load %s: %v", key, err)
+		s.arm(key, rev, retryDelay)
+		return
+	}
+	if current != rev {
+		// Changed meanwhile, the watcher arms the new revision
+		return
+	}
+
+	_, err = s.js.PublishMsg(ctx, &nats.Msg{
+		Subject: msg.Subject,
+		Data:    msg.Data,
+		Header:  msg.Header,
+	}, jetstream.WithMsgID(fmt.Sprintf("%s-%d", key, rev)))
+	if err != nil {
+		log.Printf("scheduler: publish to %s failed, retrying in %s: %v", msg.Subject, retryDelay, err)
+		s.arm(key, rev, retryDelay)
+		return
+	}
+
+	if msg.Recurring() {
+		s.advance(ctx, key, msg, rev)
+		return
+	}
+
+	if err := s.kv.Purge(ctx, key, jetstream.LastRevision(rev)); err != nil {
+		// A revision mismatch means another replica already cleaned up
+		if errors.Is(err, jetstream.ErrKeyExists) {
+			return
+		}
+		// Otherwise the entry would stay listed (and be published again on the
+		// next start). The retry publishes with the same Nats-Msg-Id, so the
+		// stream drops the copy within its duplicate window
+		log.Printf("scheduler: failed to remove %s after publish, retrying in %s: %v", key, retryDelay, err)
+		s.arm(key, rev, retryDelay)
+	}
+}
+
+// advance moves a published recurring message to its next occurrence.
+// If the bucket cannot be updated, the timer is re-armed: the retry publishes
+// with the same Nats-Msg-Id, so the stream drops the copy within its
+// duplicate window, and then advances again.
+func (s *Scheduler) advance(ctx context.Context, key string, msg *Message, rev uint64) {
+	// Start from now rather than the missed time, so a long outage does not
+	// cause a burst of catch-up publishes
+	from := msg.FireAt
+	if now := time.Now(); now.After(from) {
+		from = now
+	}
+
+	next, err := nextRun(msg, from)
+	if err != nil {
+		// The spec was valid when stored, so this replica lacks e.g. the
+		// timezone data. Pause the message rather than dropping it silently
+		log.Printf("scheduler: cannot compute next run of %s, pausing it: %v", key, err)
+		msg.Paused = true
+	} else {
+		msg.FireAt = next
+	}
+
+	value, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("scheduler: failed to encode %s: %v", key, err)
+		return
+	}
+	if _, err := s.kv.Update(ctx, key, value, rev); err != nil {
+		// A revision mismatch means another replica already advanced it
+		if errors.Is(err, jetstream.ErrKeyExists) {
+			return
+		}
+		log.Printf("scheduler: failed to advance %s, retrying in %s: %v", key, retryDelay, err)
+		s.arm(key, rev, retryDelay)
+	}
+}
+
+// purgeDeletes removes delete markers older than the library default (30 minutes),
+// long enough for every replica's watcher to have seen them.
+func (s *Scheduler) purgeDeletes(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	if err := s.kv.PurgeDeletes(ctx); err != nil {
+		log.Printf("scheduler: failed to remove delete markers: %v", err)
+	}
+}
+
+// nextRun returns the first occurrence of the message's cron spec after t.
+// Replicas may run in different timezones, so only explicit zones are accepted:
+// "Local" and a CRON_TZ= / TZ= prefix overriding Timezone are rejected.
+func nextRun(msg *Message, t time.Time) (time.Time, error) {
+	if msg.Timezone == "Local" {
+		return time.Time{}, fmt.Errorf("invalid timezone %q: use an IANA name such as \"Europe/Berlin\"", msg.Timezone)
+	}
+	if spec := strings.TrimSpace(msg.Cron); strings.HasPrefix(spec, "CRON_TZ=") || strings.HasPrefix(spec, "TZ=") {
+		return time.Time{}, fmt.Errorf("invalid cron expression %q: set the timezone argument instead of a TZ prefix", msg.Cron)
+	}
+	loc, err := time.LoadLocation(msg.Timezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timezone %q: %w", msg.Timezone, err)
+	}
+	sched, err := cron.ParseStandard(msg.Cron)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid cron expression %q: %w", msg.Cron, err)
+	}
+
+	next := sched.Next(t.In(loc))
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("cron expression %q never fires", msg.Cron)
+	}
+	return next.UTC(), nil
+}